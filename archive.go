@@ -0,0 +1,88 @@
+package easyrqst
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var ErrArchiveTooLarge = errors.New("decompressed archive exceeds size limit")
+
+// WithDecompress transparently un-gzips or unzips downloaded .gz and single-file .zip
+// bodies. The inner file name is exposed as HttpResponse.ArchiveEntry and maxSize caps
+// the decompressed size (0 means no limit).
+func WithDecompress(maxSize int64) TReqOption {
+	return func(o *ReqOptions) { o.decompress = &maxSize }
+}
+
+func (h *HttpResponse) decompress(maxSize int64) error {
+	switch {
+	case bytes.HasPrefix(h.Body, []byte{0x1f, 0x8b}):
+		reader, err := gzip.NewReader(bytes.NewReader(h.Body))
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+
+		body, err := readLimited(reader, maxSize)
+		if err != nil {
+			return err
+		}
+		h.Body, h.ArchiveEntry = body, reader.Name
+	case bytes.HasPrefix(h.Body, []byte("PK\x03\x04")):
+		archive, err := zip.NewReader(bytes.NewReader(h.Body), int64(len(h.Body)))
+		if err != nil {
+			return err
+		}
+
+		var entry *zip.File
+		for _, file := range archive.File {
+			if file.FileInfo().IsDir() {
+				continue
+			}
+			if entry != nil {
+				return fmt.Errorf("zip archive contains more than one file")
+			}
+			entry = file
+		}
+		if entry == nil {
+			return fmt.Errorf("zip archive is empty")
+		}
+		if maxSize > 0 && entry.UncompressedSize64 > uint64(maxSize) {
+			return ErrArchiveTooLarge
+		}
+
+		reader, err := entry.Open()
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+
+		body, err := readLimited(reader, maxSize)
+		if err != nil {
+			return err
+		}
+		if uint64(len(body)) != entry.UncompressedSize64 {
+			return fmt.Errorf("zip entry %s is %d bytes, expected %d", entry.Name, len(body), entry.UncompressedSize64)
+		}
+		h.Body, h.ArchiveEntry = body, entry.Name
+	}
+	return nil
+}
+
+func readLimited(reader io.Reader, maxSize int64) ([]byte, error) {
+	if maxSize <= 0 {
+		return io.ReadAll(reader)
+	}
+	body, err := io.ReadAll(io.LimitReader(reader, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > maxSize {
+		return nil, ErrArchiveTooLarge
+	}
+	return body, nil
+}
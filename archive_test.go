@@ -0,0 +1,58 @@
+package easyrqst
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDecompressDownloads(t *testing.T) {
+	var gz bytes.Buffer
+	gw := gzip.NewWriter(&gz)
+	gw.Name = "export.csv"
+	gw.Write([]byte("id,name\n1,neo\n"))
+	gw.Close()
+
+	var zipped bytes.Buffer
+	zw := zip.NewWriter(&zipped)
+	f, _ := zw.Create("report.json")
+	f.Write([]byte(`{"ok":true}`))
+	zw.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("format") == "zip" {
+			w.Write(zipped.Bytes())
+			return
+		}
+		w.Write(gz.Bytes())
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL)
+
+	outcome, err := call.Get(WithDecompress(0))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if string(outcome.Body) != "id,name\n1,neo\n" || outcome.ArchiveEntry != "export.csv" {
+		t.Errorf("Unexpected gzip result %q from %q", outcome.Body, outcome.ArchiveEntry)
+	}
+
+	outcome, err = call.Get(WithDecompress(1024), WithQueries(map[string]string{"format": "zip"}))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if string(outcome.Body) != `{"ok":true}` || outcome.ArchiveEntry != "report.json" {
+		t.Errorf("Unexpected zip result %q from %q", outcome.Body, outcome.ArchiveEntry)
+	}
+
+	if _, err := call.Get(WithDecompress(4)); !errors.Is(err, ErrArchiveTooLarge) {
+		t.Errorf("Expected ErrArchiveTooLarge, got %v", err)
+	}
+}
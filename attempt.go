@@ -0,0 +1,109 @@
+package easyrqst
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// WithAttemptTimeout gives every attempt its own deadline, so one slow attempt can't eat
+// the whole retry budget. WithTimeout still bounds the request as a whole.
+func WithAttemptTimeout(timeout time.Duration) TReqOption {
+	return func(o *ReqOptions) { o.attemptTimeout = timeout }
+}
+
+// attemptTransport runs below the retry loop and applies per-attempt settings. Every
+// attempt goes through the circuit breaker, if any.
+type attemptTransport struct {
+	base           http.RoundTripper
+	breaker        *circuitBreaker
+	sign           func(*http.Request) error
+	maxHeaderBytes int64
+	maxHeaders     int
+	hooks          hookList
+	dump           *dumper
+}
+
+func (t *attemptTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	state := stateFrom(req.Context())
+	state.request = req
+	if state.quiet {
+		return t.attempt(req)
+	}
+	t.hooks.fire(onRequest, HookEvent{Request: req, Attempt: state.attempts + 1, Elapsed: time.Since(state.start)})
+	resp, err := t.attempt(req)
+	if err == nil {
+		t.hooks.fire(onResponse, HookEvent{Request: req, Response: resp, Attempt: state.attempts + 1, Elapsed: time.Since(state.start)})
+	}
+	return resp, err
+}
+
+func (t *attemptTransport) attempt(req *http.Request) (*http.Response, error) {
+	// Retries get fresh injected fields and a fresh signature, as servers reject a
+	// replayed nonce or a stale timestamp. Redirects were signed by checkRedirect, if
+	// they may be.
+	if state := stateFrom(req.Context()); state.attempts > 0 && req.Response == nil {
+		req = req.Clone(req.Context())
+		if _, err := state.options.regenerate(req); err != nil {
+			return nil, err
+		}
+		if err := t.sign(req); err != nil {
+			return nil, err
+		}
+	}
+	if t.breaker == nil {
+		return t.roundTrip(req)
+	}
+	if err := t.breaker.allow(req.URL.Host); err != nil {
+		return nil, err
+	}
+	resp, err := t.roundTrip(req)
+	// Attempts canceled by the caller say nothing about the health of the host
+	if req.Context().Err() != nil {
+		t.breaker.abandon(req.URL.Host)
+	} else {
+		t.breaker.record(req.URL.Host, err != nil || resp.StatusCode >= 500)
+	}
+	return resp, err
+}
+
+func (t *attemptTransport) roundTrip(req *http.Request) (*http.Response, error) {
+	quiet := stateFrom(req.Context()).quiet
+	if !quiet {
+		t.dump.request(req)
+	}
+	resp, err := t.send(req)
+	resp, err = checkHeaderLimits(resp, err, t.maxHeaderBytes, t.maxHeaders)
+	if !quiet {
+		t.dump.response(resp, err)
+	}
+	return resp, err
+}
+
+func (t *attemptTransport) send(req *http.Request) (*http.Response, error) {
+	timeout := stateFrom(req.Context()).options.attemptTimeout
+	if timeout <= 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
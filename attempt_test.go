@@ -0,0 +1,34 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAttemptTimeout(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			select {
+			case <-time.After(time.Second):
+			case <-r.Context().Done():
+			}
+			return
+		}
+		w.Write([]byte("fast"))
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithRetry(2), WithRetryWaitMax(time.Millisecond*10))
+	outcome, err := call.Get(WithAttemptTimeout(time.Millisecond*100), WithTimeout(time.Millisecond*800))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if string(outcome.Body) != "fast" || calls.Load() != 2 {
+		t.Errorf("Expected second attempt to succeed, got %q after %v calls", outcome.Body, calls.Load())
+	}
+}
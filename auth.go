@@ -0,0 +1,82 @@
+package easyrqst
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Location tells where an API key is sent.
+type Location int
+
+const (
+	InHeader Location = iota
+	InQuery
+)
+
+// TTokenProvider returns the bearer token to use for a request, allowing short-lived
+// tokens to be fetched or refreshed on demand.
+type TTokenProvider func(ctx context.Context) (string, error)
+
+func WithBasicAuth(user, pass string) TReqOption {
+	return func(o *ReqOptions) {
+		o.editors = append(o.editors, func(req *http.Request) error {
+			req.SetBasicAuth(user, pass)
+			return nil
+		})
+	}
+}
+
+func WithClientBasicAuth(user, pass string) THttpOption {
+	return WithDefaults(WithBasicAuth(user, pass))
+}
+
+func WithBearerToken(token string) TReqOption {
+	return func(o *ReqOptions) {
+		o.editors = append(o.editors, func(req *http.Request) error {
+			req.Header.Set("Authorization", "Bearer "+token)
+			return nil
+		})
+	}
+}
+
+func WithTokenProvider(provider TTokenProvider) THttpOption {
+	return WithDefaults(func(o *ReqOptions) {
+		o.editors = append(o.editors, func(req *http.Request) error {
+			if explaining(req.Context()) {
+				req.Header.Set("Authorization", "Bearer REDACTED")
+				return nil
+			}
+			token, err := provider(req.Context())
+			if err != nil {
+				return fmt.Errorf("failed to get bearer token: %w", err)
+			}
+			req.Header.Set("Authorization", "Bearer "+token)
+			return nil
+		})
+	})
+}
+
+func WithAPIKey(name, value string, in Location) TReqOption {
+	return func(o *ReqOptions) {
+		switch in {
+		case InHeader:
+			o.secretHeaders = append(o.secretHeaders, name)
+		case InQuery:
+			o.secretParams = append(o.secretParams, name)
+		}
+		o.editors = append(o.editors, func(req *http.Request) error {
+			switch in {
+			case InHeader:
+				req.Header.Set(name, value)
+			case InQuery:
+				query := req.URL.Query()
+				query.Set(name, value)
+				req.URL.RawQuery = query.Encode()
+			default:
+				return fmt.Errorf("unknown api key location %d", in)
+			}
+			return nil
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package easyrqst
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newEchoAuthServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+}
+
+func TestBasicAuth(t *testing.T) {
+	server := newEchoAuthServer()
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithClientBasicAuth("neo", "matrix"))
+
+	outcome, err := call.Get()
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if string(outcome.Body) != "Basic bmVvOm1hdHJpeA==" {
+		t.Errorf("Expected client credentials, got %s", outcome.Body)
+	}
+
+	outcome, err = call.Get(WithBasicAuth("trinity", "matrix"))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if string(outcome.Body) != "Basic dHJpbml0eTptYXRyaXg=" {
+		t.Errorf("Expected request credentials to win, got %s", outcome.Body)
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	server := newEchoAuthServer()
+	defer server.Close()
+
+	calls := 0
+	call := NewHttpClient(server.URL, WithTokenProvider(func(ctx context.Context) (string, error) {
+		calls++
+		return fmt.Sprintf("token-%d", calls), nil
+	}))
+
+	for i := 1; i <= 2; i++ {
+		outcome, err := call.Get(WithContext(context.Background()))
+		if err != nil {
+			t.Errorf("Error: %v", err)
+			return
+		}
+		if expected := fmt.Sprintf("Bearer token-%d", i); string(outcome.Body) != expected {
+			t.Errorf("Expected %s, got %s", expected, outcome.Body)
+		}
+	}
+
+	outcome, err := call.Get(WithBearerToken("static"))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if string(outcome.Body) != "Bearer static" {
+		t.Errorf("Expected static token to win, got %s", outcome.Body)
+	}
+}
+
+func TestTokenProviderError(t *testing.T) {
+	call := NewHttpClient("http://localhost", WithTokenProvider(func(ctx context.Context) (string, error) {
+		return "", errors.New("expired")
+	}))
+	if _, err := call.Get(); err == nil {
+		t.Errorf("Expected token provider error")
+	}
+}
+
+func TestAPIKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get("X-Api-Key") + "|" + r.URL.RawQuery))
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithDefaults(WithAPIKey("X-Api-Key", "header-key", InHeader)))
+	outcome, err := call.Get(WithAPIKey("api_key", "query-key", InQuery), WithQueries(map[string]string{"page": "1"}))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if string(outcome.Body) != "header-key|api_key=query-key&page=1" {
+		t.Errorf("Expected api keys in header and query, got %s", outcome.Body)
+	}
+}
@@ -0,0 +1,109 @@
+package easyrqst
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// TBackoff computes how long to wait before retry attempt (starting at 0), bounded by
+// the client's RetryWaitMin and RetryWaitMax.
+type TBackoff func(min, max time.Duration, attempt int, resp *http.Response) time.Duration
+
+// ConstantBackoff always waits min.
+func ConstantBackoff(min, max time.Duration, attempt int, resp *http.Response) time.Duration {
+	return min
+}
+
+// LinearBackoff waits min, 2*min, 3*min... up to max.
+func LinearBackoff(min, max time.Duration, attempt int, resp *http.Response) time.Duration {
+	return capWait(float64(min)*float64(attempt+1), max)
+}
+
+// ExponentialBackoff waits min, 2*min, 4*min... up to max.
+func ExponentialBackoff(min, max time.Duration, attempt int, resp *http.Response) time.Duration {
+	return capWait(float64(min)*math.Pow(2, float64(attempt)), max)
+}
+
+// FullJitterBackoff waits a random duration between 0 and the exponential backoff, which
+// spreads clients that failed together across the whole window.
+func FullJitterBackoff(min, max time.Duration, attempt int, resp *http.Response) time.Duration {
+	return time.Duration(rand.Int63n(int64(ExponentialBackoff(min, max, attempt, resp)) + 1))
+}
+
+// DecorrelatedJitterBackoff waits a random duration between min and three times the
+// previous wait, up to max.
+func DecorrelatedJitterBackoff(min, max time.Duration, attempt int, resp *http.Response) time.Duration {
+	wait := min
+	for i := 0; i <= attempt; i++ {
+		upper := capWait(float64(wait)*3, max)
+		if upper <= min {
+			return upper
+		}
+		wait = min + time.Duration(rand.Int63n(int64(upper-min)+1))
+	}
+	return wait
+}
+
+func capWait(wait float64, max time.Duration) time.Duration {
+	if wait > float64(max) {
+		return max
+	}
+	return time.Duration(wait)
+}
+
+// WithBackoff replaces the default exponential backoff.
+func WithBackoff(backoff TBackoff) THttpOption {
+	return func(o *easyRequest) { o.backoff = backoff }
+}
+
+func WithRetryWaitMin(wait time.Duration) THttpOption {
+	return func(o *easyRequest) { o.retryWaitMin = wait }
+}
+
+// WithRetryAfter controls whether the Retry-After header of 429 and 503 responses takes
+// precedence over the backoff strategy. It is honored by default.
+func WithRetryAfter(honor bool) THttpOption {
+	return func(o *easyRequest) { o.ignoreRetryAfter = !honor }
+}
+
+// WithRetryAfterCap bounds how long a Retry-After header can make the client wait.
+func WithRetryAfterCap(max time.Duration) THttpOption {
+	return func(o *easyRequest) { o.retryAfterCap = max }
+}
+
+func (h *easyRequest) retryBackoff(min, max time.Duration, attempt int, resp *http.Response) time.Duration {
+	if !h.ignoreRetryAfter && resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if wait, ok := h.retryAfter(resp.Header.Get("Retry-After")); ok {
+			if wait > h.retryAfterCap {
+				return h.retryAfterCap
+			}
+			return wait
+		}
+	}
+	backoff := h.backoff
+	if backoff == nil {
+		backoff = ExponentialBackoff
+	}
+	return backoff(min, max, attempt, resp)
+}
+
+// retryAfter parses Retry-After given either in seconds or as an HTTP date.
+func (h *easyRequest) retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Duration(seconds) * time.Second, seconds >= 0
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if wait := date.Sub(h.now()); wait > 0 {
+		return wait, true
+	}
+	return 0, true
+}
@@ -0,0 +1,81 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestBackoffStrategies(t *testing.T) {
+	min, max := 100*time.Millisecond, time.Second
+	for attempt, expected := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second} {
+		if wait := ExponentialBackoff(min, max, attempt, nil); wait != expected {
+			t.Errorf("Exponential attempt %d: expected %v, got %v", attempt, expected, wait)
+		}
+		if wait := FullJitterBackoff(min, max, attempt, nil); wait < 0 || wait > expected {
+			t.Errorf("Full jitter attempt %d: expected at most %v, got %v", attempt, expected, wait)
+		}
+		if wait := DecorrelatedJitterBackoff(min, max, attempt, nil); wait < min || wait > max {
+			t.Errorf("Decorrelated jitter attempt %d: expected between %v and %v, got %v", attempt, min, max, wait)
+		}
+	}
+	if wait := LinearBackoff(min, max, 2, nil); wait != 300*time.Millisecond {
+		t.Errorf("Linear: expected 300ms, got %v", wait)
+	}
+	if wait := ConstantBackoff(min, max, 5, nil); wait != min {
+		t.Errorf("Constant: expected %v, got %v", min, wait)
+	}
+}
+
+func TestWithBackoff(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var attempts []int
+	backoff := func(min, max time.Duration, attempt int, resp *http.Response) time.Duration {
+		attempts = append(attempts, attempt)
+		return ConstantBackoff(min, max, attempt, resp)
+	}
+
+	call := NewHttpClient(server.URL, WithRetry(2), WithRetryWaitMin(time.Millisecond), WithBackoff(backoff))
+	if _, err := call.Get(); err == nil {
+		t.Errorf("Expected error after retries are exhausted")
+	}
+	if calls != 3 || len(attempts) != 2 {
+		t.Errorf("Expected 3 calls and 2 backoffs, got %v calls and %v backoffs", calls, len(attempts))
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	call := NewHttpClient("http://localhost", WithClock(func() time.Time { return now }), WithRetryAfterCap(time.Minute)).(*easyRequest)
+
+	respond := func(status int, retryAfter string) *http.Response {
+		return &http.Response{StatusCode: status, Header: http.Header{"Retry-After": []string{retryAfter}}}
+	}
+	cases := []struct {
+		resp     *http.Response
+		expected time.Duration
+	}{
+		{respond(http.StatusTooManyRequests, "5"), 5 * time.Second},
+		{respond(http.StatusServiceUnavailable, now.Add(30*time.Second).Format(http.TimeFormat)), 30 * time.Second},
+		{respond(http.StatusTooManyRequests, "3600"), time.Minute},
+		{respond(http.StatusTooManyRequests, "soon"), time.Second},
+		{respond(http.StatusInternalServerError, "5"), time.Second},
+	}
+	for _, c := range cases {
+		if wait := call.retryBackoff(time.Second, time.Second, 0, c.resp); wait != c.expected {
+			t.Errorf("Retry-After %q: expected %v, got %v", c.resp.Header.Get("Retry-After"), c.expected, wait)
+		}
+	}
+
+	call = NewHttpClient("http://localhost", WithRetryAfter(false)).(*easyRequest)
+	if wait := call.retryBackoff(time.Second, time.Second, 0, respond(http.StatusTooManyRequests, "5")); wait != time.Second {
+		t.Errorf("Expected Retry-After to be ignored, got %v", wait)
+	}
+}
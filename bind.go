@@ -0,0 +1,139 @@
+package easyrqst
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+var (
+	pathParamRe    = regexp.MustCompile(`\{([^}]+)\}`)
+	contextType    = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType      = reflect.TypeOf((*error)(nil)).Elem()
+	reqOptionType  = reflect.TypeOf(TReqOption(nil))
+	httpOutputType = reflect.TypeOf((*HttpResponse)(nil))
+)
+
+// Bind implements the func fields of api as calls through client. Each field is tagged
+// with `method:"GET" path:"/users/{id}"` and has the shape
+//
+//	func([ctx context.Context,] <one arg per path param>, [body any,] [opts ...TReqOption]) ([T,] error)
+//
+// Path params are formatted with %v and escaped, the body is sent as the payload and
+// a 2xx response is decoded as JSON into T (or returned as is when T is *HttpResponse).
+func Bind(client IHttpClient, api any) error {
+	rv := reflect.ValueOf(api)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("bind target must be a pointer to a struct, got %T", api)
+	}
+	rv = rv.Elem()
+
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Type().Field(i)
+		method, path := field.Tag.Get("method"), field.Tag.Get("path")
+		if method == "" {
+			continue
+		}
+		if field.Type.Kind() != reflect.Func || !field.IsExported() {
+			return fmt.Errorf("field %s must be an exported func", field.Name)
+		}
+		fn, err := bindFunc(client, method, path, field.Type)
+		if err != nil {
+			return fmt.Errorf("field %s: %v", field.Name, err)
+		}
+		rv.Field(i).Set(fn)
+	}
+	return nil
+}
+
+func bindFunc(client IHttpClient, method, path string, ft reflect.Type) (reflect.Value, error) {
+	params := pathParamRe.FindAllStringSubmatch(path, -1)
+
+	in := 0
+	hasCtx := ft.NumIn() > 0 && ft.In(0) == contextType
+	if hasCtx {
+		in++
+	}
+	pathArgs := in
+	in += len(params)
+
+	hasOpts := ft.IsVariadic() && ft.In(ft.NumIn()-1) == reflect.SliceOf(reqOptionType)
+	last := ft.NumIn()
+	if hasOpts {
+		last--
+	}
+	if in > last {
+		return reflect.Value{}, fmt.Errorf("expected %d path params", len(params))
+	}
+	hasBody := last-in == 1
+	if last-in > 1 {
+		return reflect.Value{}, fmt.Errorf("too many arguments")
+	}
+
+	if ft.NumOut() == 0 || ft.NumOut() > 2 || ft.Out(ft.NumOut()-1) != errorType {
+		return reflect.Value{}, fmt.Errorf("must return ([T,] error)")
+	}
+	var outType reflect.Type
+	if ft.NumOut() == 2 {
+		outType = ft.Out(0)
+	}
+
+	return reflect.MakeFunc(ft, func(args []reflect.Value) []reflect.Value {
+		var opts []TReqOption
+		if hasCtx && !args[0].IsNil() {
+			opts = append(opts, WithContext(args[0].Interface().(context.Context)))
+		}
+
+		resolved := path
+		for i, param := range params {
+			value := url.PathEscape(fmt.Sprintf("%v", args[pathArgs+i].Interface()))
+			resolved = strings.Replace(resolved, param[0], value, 1)
+		}
+		opts = append(opts, WithPath(resolved))
+
+		if hasBody {
+			opts = append(opts, WithPayload(args[in].Interface()))
+		}
+		if hasOpts {
+			opts = append(opts, args[len(args)-1].Interface().([]TReqOption)...)
+		}
+
+		outcome, err := client.Custom(method, opts...)
+		if err == nil && (outcome.StatusCode < 200 || outcome.StatusCode >= 300) {
+			err = fmt.Errorf("%s %s: unexpected status code %d", method, resolved, outcome.StatusCode)
+		}
+		return bindResults(outType, outcome, err)
+	}), nil
+}
+
+func bindResults(outType reflect.Type, outcome *HttpResponse, err error) []reflect.Value {
+	errValue := reflect.Zero(errorType)
+	if outType == nil {
+		if err != nil {
+			errValue = reflect.ValueOf(&err).Elem()
+		}
+		return []reflect.Value{errValue}
+	}
+
+	out := reflect.Zero(outType)
+	if err == nil {
+		if outType == httpOutputType {
+			out = reflect.ValueOf(outcome)
+		} else if len(outcome.Body) > 0 {
+			target := reflect.New(outType)
+			if decodeErr := json.Unmarshal(outcome.Body, target.Interface()); decodeErr != nil {
+				err = fmt.Errorf("failed to decode response: %w", decodeErr)
+			} else {
+				out = target.Elem()
+			}
+		}
+	}
+	if err != nil {
+		errValue = reflect.ValueOf(&err).Elem()
+	}
+	return []reflect.Value{out, errValue}
+}
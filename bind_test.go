@@ -0,0 +1,67 @@
+package easyrqst
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type bindUser struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type bindAPI struct {
+	GetUser    func(ctx context.Context, id string) (*bindUser, error)                         `method:"GET" path:"/users/{id}"`
+	CreateUser func(ctx context.Context, user *bindUser, opts ...TReqOption) (*bindUser, error) `method:"POST" path:"/users"`
+	DeleteUser func(id int) error                                                               `method:"DELETE" path:"/users/{id}"`
+}
+
+func TestBind(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.EscapedPath() == "/v1/users/a%2Fb":
+			json.NewEncoder(w).Encode(bindUser{ID: "a/b", Name: "neo"})
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/users" && r.URL.Query().Get("notify") == "1":
+			var user bindUser
+			json.NewDecoder(r.Body).Decode(&user)
+			user.ID = "42"
+			json.NewEncoder(w).Encode(user)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	var api bindAPI
+	if err := Bind(NewHttpClient(server.URL+"/v1"), &api); err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+
+	user, err := api.GetUser(context.Background(), "a/b")
+	if err != nil || user.Name != "neo" {
+		t.Errorf("Unexpected result %+v, %v", user, err)
+	}
+
+	created, err := api.CreateUser(context.Background(), &bindUser{Name: "trinity"}, WithQueries(map[string]string{"notify": "1"}))
+	if err != nil || created.ID != "42" || created.Name != "trinity" {
+		t.Errorf("Unexpected result %+v, %v", created, err)
+	}
+
+	if err := api.DeleteUser(7); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Expected status error, got %v", err)
+	}
+}
+
+func TestBindRejectsInvalidSignature(t *testing.T) {
+	var api struct {
+		Get func(id string) string `method:"GET" path:"/users/{id}"`
+	}
+	if err := Bind(NewHttpClient("http://localhost"), &api); err == nil {
+		t.Errorf("Expected error for missing error result")
+	}
+}
@@ -0,0 +1,105 @@
+package easyrqst
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+type circuit struct {
+	state        circuitState
+	failures     int
+	firstFailure time.Time
+	openedAt     time.Time
+	probing      bool
+}
+
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	window    time.Duration
+	cooldown  time.Duration
+	circuits  map[string]*circuit
+}
+
+// WithCircuitBreaker opens the circuit of a host after threshold failed attempts (errors
+// or 5xx) within window, failing calls with ErrCircuitOpen without retrying them. After
+// cooldown a single probe is let through; its success closes the circuit again.
+func WithCircuitBreaker(threshold int, window, cooldown time.Duration) THttpOption {
+	return func(o *easyRequest) {
+		o.breaker = &circuitBreaker{threshold: threshold, window: window, cooldown: cooldown, circuits: make(map[string]*circuit)}
+	}
+}
+
+func (b *circuitBreaker) allow(host string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuits[host]
+	if c == nil {
+		return nil
+	}
+	switch c.state {
+	case circuitOpen:
+		if time.Since(c.openedAt) < b.cooldown {
+			return ErrCircuitOpen
+		}
+		c.state = circuitHalfOpen
+		c.probing = true
+	case circuitHalfOpen:
+		if c.probing {
+			return ErrCircuitOpen
+		}
+		c.probing = true
+	}
+	return nil
+}
+
+func (b *circuitBreaker) record(host string, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuits[host]
+	if !failed {
+		if c != nil {
+			delete(b.circuits, host)
+		}
+		return
+	}
+	if c == nil {
+		c = &circuit{}
+		b.circuits[host] = c
+	}
+
+	now := time.Now()
+	if c.state == circuitHalfOpen {
+		c.state, c.openedAt, c.probing = circuitOpen, now, false
+		return
+	}
+	if c.failures == 0 || now.Sub(c.firstFailure) > b.window {
+		c.failures, c.firstFailure = 0, now
+	}
+	c.failures++
+	if c.failures >= b.threshold {
+		c.state, c.openedAt = circuitOpen, now
+	}
+}
+
+// abandon lets another probe through when a probe ended without an outcome.
+func (b *circuitBreaker) abandon(host string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if c := b.circuits[host]; c != nil {
+		c.probing = false
+	}
+}
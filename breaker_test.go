@@ -0,0 +1,63 @@
+package easyrqst
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	healthy := false
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if !healthy {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithRetry(5), WithRetryWaitMin(time.Millisecond), WithRetryWaitMax(time.Millisecond),
+		WithCircuitBreaker(3, time.Minute, 50*time.Millisecond))
+
+	if _, err := call.Get(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected retries to stop once the circuit opened, got %v calls", calls)
+	}
+	if _, err := call.Get(); !errors.Is(err, ErrCircuitOpen) || calls != 3 {
+		t.Errorf("Expected call to be short-circuited, got %v after %v calls", err, calls)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	healthy = true
+	outcome, err := call.Get()
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if outcome.StatusCode != http.StatusOK || calls != 4 {
+		t.Errorf("Expected the probe to close the circuit, got %v after %v calls", outcome.StatusCode, calls)
+	}
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	breaker := &circuitBreaker{threshold: 1, window: time.Minute, cooldown: 0, circuits: make(map[string]*circuit)}
+	breaker.record("api", true)
+	if err := breaker.allow("api"); err != nil {
+		t.Errorf("Expected a probe after cooldown, got %v", err)
+	}
+	if err := breaker.allow("api"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected a single probe at a time, got %v", err)
+	}
+	breaker.record("api", true)
+	if c := breaker.circuits["api"]; c.state != circuitOpen {
+		t.Errorf("Expected failed probe to reopen the circuit")
+	}
+	if err := breaker.allow("other"); err != nil {
+		t.Errorf("Expected circuits per host, got %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+package easyrqst
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+var ErrBudgetExceeded = errors.New("latency budget exceeded, low priority request shed")
+
+// BudgetStatus is the state of the latency budget over the rolling window.
+type BudgetStatus struct {
+	Target    time.Duration
+	P99       time.Duration
+	ErrorRate float64
+	Samples   int
+	Violated  bool
+}
+
+// The window is kept as budgetSlices time slices, each a histogram of latencies with
+// budgetBuckets buckets a quarter octave apart around the target, so recording a
+// request costs the same however busy the client is. The p99 is the upper bound of its
+// bucket; one of the bounds is the target, so whether it is violated is exact.
+const (
+	budgetSlices  = 10
+	budgetBuckets = 64
+)
+
+type budgetSlice struct {
+	slot     int64
+	counts   [budgetBuckets]int
+	samples  int
+	failures int
+}
+
+type latencyBudget struct {
+	mu       sync.Mutex
+	target   time.Duration
+	window   time.Duration
+	bounds   [budgetBuckets]time.Duration
+	slices   [budgetSlices]budgetSlice
+	violated bool
+}
+
+func newLatencyBudget(target, window time.Duration) *latencyBudget {
+	b := &latencyBudget{target: target, window: window}
+	for i := range b.bounds {
+		b.bounds[i] = time.Duration(float64(target) * math.Pow(2, float64(i-budgetBuckets/2)/4))
+	}
+	return b
+}
+
+// WithLatencyBudget tracks the p99 latency and error rate of requests over a rolling
+// window. The budget is violated while the p99 is above target.
+func WithLatencyBudget(p99, window time.Duration) THttpOption {
+	return func(o *easyRequest) { o.budget = newLatencyBudget(p99, window) }
+}
+
+// WithBudgetHook is called whenever the latency budget becomes violated or recovers.
+func WithBudgetHook(hook func(BudgetStatus)) THttpOption {
+	return func(o *easyRequest) { o.budgetHook = hook }
+}
+
+// WithLoadShedding fails PriorityLow requests with ErrBudgetExceeded while the latency
+// budget is violated, leaving the capacity to more important traffic.
+func WithLoadShedding() THttpOption {
+	return func(o *easyRequest) { o.shedLow = true }
+}
+
+func (b *latencyBudget) slot(now time.Time) int64 {
+	length := b.window / budgetSlices
+	if length <= 0 {
+		length = 1
+	}
+	return now.UnixNano() / int64(length)
+}
+
+func (b *latencyBudget) record(now time.Time, latency time.Duration, failed bool) (BudgetStatus, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	slot := b.slot(now)
+	current := &b.slices[slot%budgetSlices]
+	if current.slot != slot {
+		*current = budgetSlice{slot: slot}
+	}
+	bucket := sort.Search(budgetBuckets, func(i int) bool { return latency <= b.bounds[i] })
+	if bucket == budgetBuckets {
+		bucket--
+	}
+	current.counts[bucket]++
+	current.samples++
+	if failed {
+		current.failures++
+	}
+	return b.update(slot)
+}
+
+// check recomputes the status at now, as the window also moves while no request
+// completes, e.g. while every request is shed.
+func (b *latencyBudget) check(now time.Time) (BudgetStatus, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.update(b.slot(now))
+}
+
+// update computes the status over the slices in the window ending at slot; callers
+// hold the lock.
+func (b *latencyBudget) update(slot int64) (BudgetStatus, bool) {
+	var counts [budgetBuckets]int
+	samples, failures := 0, 0
+	for _, s := range b.slices {
+		if s.samples == 0 || slot-s.slot >= budgetSlices {
+			continue
+		}
+		for i, n := range s.counts {
+			counts[i] += n
+		}
+		samples += s.samples
+		failures += s.failures
+	}
+
+	status := BudgetStatus{Target: b.target, Samples: samples}
+	if samples > 0 {
+		status.ErrorRate = float64(failures) / float64(samples)
+	}
+	rank, seen := (samples*99-1)/100+1, 0
+	for i, n := range counts {
+		if seen += n; seen >= rank {
+			status.P99 = b.bounds[i]
+			status.Violated = i > budgetBuckets/2
+			break
+		}
+	}
+	changed := status.Violated != b.violated
+	b.violated = status.Violated
+	return status, changed
+}
+
+func (h *easyRequest) shed(options *ReqOptions) error {
+	if h.budget == nil || !h.shedLow || options.priority > PriorityLow {
+		return nil
+	}
+	status, changed := h.budget.check(time.Now())
+	h.reportBudget(status, changed)
+	if status.Violated {
+		return ErrBudgetExceeded
+	}
+	return nil
+}
+
+func (h *easyRequest) reportBudget(status BudgetStatus, changed bool) {
+	if changed && h.budgetHook != nil {
+		h.budgetHook(status)
+	}
+}
+
+func (h *easyRequest) trackBudget(start time.Time, statusCode int, err error) {
+	if h.budget == nil {
+		return
+	}
+	now := time.Now()
+	h.reportBudget(h.budget.record(now, now.Sub(start), err != nil || statusCode >= 500))
+}
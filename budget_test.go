@@ -0,0 +1,84 @@
+package easyrqst
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLatencyBudgetSheddingAndHook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("slow") != "" {
+			time.Sleep(50 * time.Millisecond)
+		}
+	}))
+	defer server.Close()
+
+	var reports []BudgetStatus
+	call := NewHttpClient(server.URL, WithLatencyBudget(20*time.Millisecond, time.Minute), WithLoadShedding(), WithBudgetHook(func(s BudgetStatus) {
+		reports = append(reports, s)
+	}))
+
+	if _, err := call.Get(WithQueries(map[string]string{"slow": "1"})); err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if len(reports) != 1 || !reports[0].Violated || reports[0].Samples != 1 {
+		t.Errorf("Expected a violation report, got %+v", reports)
+	}
+
+	if _, err := call.Get(WithPriority(PriorityLow)); !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("Expected low priority request to be shed, got %v", err)
+	}
+	if _, err := call.Get(); err != nil {
+		t.Errorf("Expected normal priority request to go through, got %v", err)
+	}
+}
+
+func TestLatencyBudgetWindow(t *testing.T) {
+	budget := newLatencyBudget(10*time.Millisecond, time.Second)
+	now := time.Now()
+	if status, changed := budget.record(now, time.Second, true); !status.Violated || !changed || status.ErrorRate != 1 {
+		t.Errorf("Expected violation, got %+v", status)
+	}
+	status, changed := budget.record(now.Add(2*time.Second), time.Millisecond, false)
+	if status.Violated || !changed || status.Samples != 1 || status.ErrorRate != 0 {
+		t.Errorf("Expected old samples to leave the window, got %+v", status)
+	}
+}
+
+func TestLatencyBudgetRecoversWhileShedding(t *testing.T) {
+	budget := newLatencyBudget(10*time.Millisecond, time.Second)
+	now := time.Now()
+	budget.record(now, time.Second, false)
+	if status, _ := budget.check(now); !status.Violated {
+		t.Errorf("Expected violation, got %+v", status)
+	}
+	if status, changed := budget.check(now.Add(2 * time.Second)); status.Violated || !changed || status.Samples != 0 {
+		t.Errorf("Expected the budget to recover once the window passed, got %+v", status)
+	}
+}
+
+func TestLatencyBudgetPercentile(t *testing.T) {
+	budget := newLatencyBudget(10*time.Millisecond, time.Minute)
+	now := time.Now()
+	var status BudgetStatus
+	for i := 0; i < 990; i++ {
+		status, _ = budget.record(now, 10*time.Millisecond, false)
+	}
+	for i := 0; i < 10; i++ {
+		status, _ = budget.record(now, time.Second, false)
+	}
+	if status.Violated || status.P99 != 10*time.Millisecond || status.Samples != 1000 {
+		t.Errorf("Expected the p99 to be at the target, got %+v", status)
+	}
+
+	for i := 0; i < 10; i++ {
+		status, _ = budget.record(now, time.Second, false)
+	}
+	if !status.Violated || status.P99 < time.Second || status.P99 > 1200*time.Millisecond {
+		t.Errorf("Expected the p99 to be about a second, got %+v", status)
+	}
+}
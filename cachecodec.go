@@ -0,0 +1,187 @@
+package easyrqst
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ICacheCodec turns responses into the bytes stored in caches, and back.
+type ICacheCodec interface {
+	Encode(response *HttpResponse) ([]byte, error)
+	Decode(data []byte) (*HttpResponse, error)
+}
+
+const cacheFormatVersion = 1
+
+// cachedResponse is what codecs store. Version is bumped on incompatible changes, so
+// entries written by older releases are refetched instead of misread.
+type cachedResponse struct {
+	Version      int
+	Method       string
+	StatusCode   int
+	Header       http.Header
+	Body         []byte
+	CachedAt     time.Time
+	MaxAge       time.Duration
+	Varied       map[string]string
+	APIVersion   string
+	ArchiveEntry string
+	FinalURL     string
+}
+
+func toCached(response *HttpResponse) cachedResponse {
+	return cachedResponse{
+		Version:      cacheFormatVersion,
+		Method:       response.method,
+		StatusCode:   response.StatusCode,
+		Header:       response.Header,
+		Body:         response.Body,
+		CachedAt:     response.CachedAt,
+		MaxAge:       response.maxAge,
+		Varied:       response.varied,
+		APIVersion:   response.APIVersion,
+		ArchiveEntry: response.ArchiveEntry,
+		FinalURL:     response.FinalURL,
+	}
+}
+
+func fromCached(entry cachedResponse) (*HttpResponse, error) {
+	if entry.Version != cacheFormatVersion {
+		return nil, fmt.Errorf("unsupported cache entry version %d", entry.Version)
+	}
+	return &HttpResponse{
+		method:       entry.Method,
+		StatusCode:   entry.StatusCode,
+		Header:       entry.Header,
+		Body:         entry.Body,
+		CachedAt:     entry.CachedAt,
+		maxAge:       entry.MaxAge,
+		varied:       entry.Varied,
+		APIVersion:   entry.APIVersion,
+		ArchiveEntry: entry.ArchiveEntry,
+		FinalURL:     entry.FinalURL,
+	}, nil
+}
+
+// GobCodec stores responses with encoding/gob. It is the default codec.
+type GobCodec struct{}
+
+func (GobCodec) Encode(response *HttpResponse) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(toCached(response)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (GobCodec) Decode(data []byte) (*HttpResponse, error) {
+	var entry cachedResponse
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&entry); err != nil {
+		return nil, err
+	}
+	return fromCached(entry)
+}
+
+// JSONCodec stores responses as JSON, with the body base64 encoded.
+type JSONCodec struct{}
+
+func (JSONCodec) Encode(response *HttpResponse) ([]byte, error) {
+	return json.Marshal(toCached(response))
+}
+
+func (JSONCodec) Decode(data []byte) (*HttpResponse, error) {
+	var entry cachedResponse
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+	return fromCached(entry)
+}
+
+// GzipCodec gzips entries of Codec, GobCodec by default, once they reach MinSize bytes.
+// Smaller entries are stored as is and both kinds are read back.
+type GzipCodec struct {
+	Codec   ICacheCodec
+	MinSize int
+	Level   int
+}
+
+func (c GzipCodec) inner() ICacheCodec {
+	if c.Codec == nil {
+		return GobCodec{}
+	}
+	return c.Codec
+}
+
+func (c GzipCodec) Encode(response *HttpResponse) ([]byte, error) {
+	data, err := c.inner().Encode(response)
+	if err != nil || len(data) < c.MinSize {
+		return data, err
+	}
+	level := c.Level
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+
+	var buf bytes.Buffer
+	writer, err := gzip.NewWriterLevel(&buf, level)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c GzipCodec) Decode(data []byte) (*HttpResponse, error) {
+	if !bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		return c.inner().Decode(data)
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	data, err = io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	return c.inner().Decode(data)
+}
+
+// WithCacheCodec sets how responses are serialized into caches.
+func WithCacheCodec(codec ICacheCodec) THttpOption {
+	return func(o *easyRequest) { o.codec = codec }
+}
+
+func (h *easyRequest) encodeCached(response *HttpResponse) ([]byte, error) {
+	if h.codec == nil {
+		return GobCodec{}.Encode(response)
+	}
+	return h.codec.Encode(response)
+}
+
+// decodeCached reads a cache entry. Caches backed by remote stores may hand back the
+// stored bytes as a string; entries stored by older releases hold the response itself.
+func (h *easyRequest) decodeCached(cached any) (*HttpResponse, error) {
+	codec := h.codec
+	if codec == nil {
+		codec = GobCodec{}
+	}
+	switch v := cached.(type) {
+	case []byte:
+		return codec.Decode(v)
+	case string:
+		return codec.Decode([]byte(v))
+	}
+	return toStruct[any, *HttpResponse](cached), nil
+}
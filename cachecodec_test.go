@@ -0,0 +1,165 @@
+package easyrqst
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type countingCodec struct {
+	GobCodec
+	encoded, decoded int
+}
+
+func (c *countingCodec) Encode(response *HttpResponse) ([]byte, error) {
+	c.encoded++
+	return c.GobCodec.Encode(response)
+}
+
+func (c *countingCodec) Decode(data []byte) (*HttpResponse, error) {
+	c.decoded++
+	return c.GobCodec.Decode(data)
+}
+
+func TestCacheCodecRoundTrip(t *testing.T) {
+	binary := []byte{0x89, 'P', 'N', 'G', 0xff, 0x00, 0xfe}
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Add("X-Tag", "a")
+		w.Header().Add("X-Tag", "b")
+		w.Write(binary)
+	}))
+	defer server.Close()
+
+	cache := newMapCache()
+	call := NewHttpClient(server.URL)
+	if _, err := call.Get(WithCache(cache, time.Minute, "png")); err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	for key, value := range cache.items {
+		if _, ok := value.([]byte); !ok {
+			t.Errorf("Expected %s to be stored encoded, got %T", key, value)
+		}
+	}
+
+	resp, err := call.Get(WithCache(cache, time.Minute, "png"))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if !resp.FromCache || calls != 1 {
+		t.Errorf("Expected a cache hit, got %v after %d calls", resp.FromCache, calls)
+	}
+	if !bytes.Equal(resp.Body, binary) || len(resp.Header.Values("X-Tag")) != 2 || resp.method != http.MethodGet {
+		t.Errorf("Expected a faithful copy, got %v %v %q", resp.Body, resp.Header, resp.method)
+	}
+}
+
+func TestCacheCodecUnreadableEntry(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte("fresh"))
+	}))
+	defer server.Close()
+
+	cache := newMapCache()
+	codec := &countingCodec{}
+	call := NewHttpClient(server.URL, WithCacheCodec(codec))
+	call.Get(WithCache(cache, time.Minute, "entry"))
+	for key := range cache.items {
+		cache.items[key] = "not a gob stream"
+	}
+
+	resp, err := call.Get(WithCache(cache, time.Minute, "entry"))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if resp.FromCache || string(resp.Body) != "fresh" || calls != 2 {
+		t.Errorf("Expected an unreadable entry to be refetched, got %v after %d calls", resp.FromCache, calls)
+	}
+	if codec.encoded != 2 || codec.decoded != 1 {
+		t.Errorf("Expected the custom codec to be used, got %d encodes and %d decodes", codec.encoded, codec.decoded)
+	}
+}
+
+func TestCacheCodecs(t *testing.T) {
+	response := &HttpResponse{
+		method:     http.MethodPost,
+		StatusCode: http.StatusCreated,
+		Header:     http.Header{"Content-Type": {"application/json"}, "Set-Cookie": {"a=1", "b=2"}},
+		Body:       bytes.Repeat([]byte(`{"id":1,"name":"neo"},`), 200),
+		CachedAt:   time.Unix(1700000000, 123),
+		APIVersion: "2024-01-01",
+		FinalURL:   "https://api.example.com/users",
+		varied:     map[string]string{"Accept-Language": "en"},
+	}
+
+	codecs := map[string]ICacheCodec{
+		"gob":          GobCodec{},
+		"json":         JSONCodec{},
+		"msgpack":      MsgpackCodec{},
+		"gzip":         GzipCodec{},
+		"gzip+msgpack": GzipCodec{Codec: MsgpackCodec{}, Level: 9},
+	}
+	sizes := map[string]int{}
+	for name, codec := range codecs {
+		data, err := codec.Encode(response)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		sizes[name] = len(data)
+		decoded, err := codec.Decode(data)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if decoded.method != response.method || decoded.StatusCode != response.StatusCode || !bytes.Equal(decoded.Body, response.Body) ||
+			len(decoded.Header["Set-Cookie"]) != 2 || !decoded.CachedAt.Equal(response.CachedAt) || decoded.APIVersion != response.APIVersion || decoded.FinalURL != response.FinalURL ||
+			!sameVariant(decoded.varied, response.varied) {
+			t.Errorf("%s: expected a faithful copy, got %+v", name, decoded)
+		}
+	}
+	if sizes["gzip"] >= sizes["gob"]/4 || sizes["gzip+msgpack"] >= sizes["msgpack"]/4 {
+		t.Errorf("Expected compression to shrink repetitive bodies, got %v", sizes)
+	}
+}
+
+func TestGzipCodecMinSize(t *testing.T) {
+	codec := GzipCodec{MinSize: 1024}
+	small := &HttpResponse{StatusCode: http.StatusOK, Body: []byte("ok")}
+	data, err := codec.Encode(small)
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		t.Errorf("Expected small entries to be stored uncompressed")
+	}
+	if decoded, err := codec.Decode(data); err != nil || string(decoded.Body) != "ok" {
+		t.Errorf("Expected uncompressed entries to be read back, got %v", err)
+	}
+}
+
+func TestMsgpackCodecForeignEncoding(t *testing.T) {
+	// {"version": uint8 1, "status": int16 200, "body": str8 "hi"} as another encoder may write it
+	data := []byte{0x83,
+		0xa7, 'v', 'e', 'r', 's', 'i', 'o', 'n', 0xcc, 0x01,
+		0xa6, 's', 't', 'a', 't', 'u', 's', 0xd1, 0x00, 0xc8,
+		0xa4, 'b', 'o', 'd', 'y', 0xc4, 0x02, 'h', 'i',
+	}
+	response, err := MsgpackCodec{}.Decode(data)
+	if err != nil || response.StatusCode != http.StatusOK || string(response.Body) != "hi" {
+		t.Errorf("Expected the entry to decode, got %+v (%v)", response, err)
+	}
+	if _, err := (MsgpackCodec{}).Decode(data[:12]); err == nil {
+		t.Errorf("Expected a truncated entry to fail")
+	}
+}
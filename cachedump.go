@@ -0,0 +1,162 @@
+package easyrqst
+
+import (
+	"container/list"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// cacheIndex remembers which responses the client stored, and until when, so they can
+// be dumped from caches that can't be enumerated and invalidated by pattern. It holds
+// up to max entries, dropping the oldest ones; expired entries are dropped lazily.
+type cacheIndex struct {
+	mu    sync.Mutex
+	max   int
+	order *list.List
+	items map[string]*list.Element
+}
+
+type indexedEntry struct {
+	key     string
+	cache   ICacheFn
+	expires time.Time
+	// resource is the host and path of GET and HEAD entries, invalidated by writes to it
+	resource string
+}
+
+func (e *indexedEntry) expired(now time.Time) bool {
+	return !e.expires.IsZero() && e.expires.Before(now)
+}
+
+type dumpedEntry struct {
+	Key     string    `json:"key"`
+	Expires time.Time `json:"expires,omitempty"`
+	// Response is the envelope the cache codecs store, which keeps the request headers
+	// the response varies on
+	Response cachedResponse `json:"response"`
+}
+
+// WithCacheIndex keeps track of up to maxEntries responses the client cached (10000 if
+// maxEntries is 0), which DumpCache, InvalidateCache patterns and the invalidation of
+// cached reads by writes rely on. The oldest entries are forgotten first.
+func WithCacheIndex(maxEntries int) THttpOption {
+	return func(o *easyRequest) {
+		if maxEntries <= 0 {
+			maxEntries = 10000
+		}
+		o.cacheIndex = &cacheIndex{max: maxEntries, order: list.New(), items: make(map[string]*list.Element)}
+	}
+}
+
+func (c *cacheIndex) track(key string, cache ICacheFn, expiry time.Duration, resource string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	entry := &indexedEntry{key: key, cache: cache, resource: resource}
+	if expiry > 0 {
+		entry.expires = now.Add(expiry)
+	}
+	if el, ok := c.items[key]; ok {
+		c.order.Remove(el)
+	}
+	c.items[key] = c.order.PushBack(entry)
+	for front := c.order.Front(); front != nil && (c.order.Len() > c.max || front.Value.(*indexedEntry).expired(now)); front = c.order.Front() {
+		c.order.Remove(front)
+		delete(c.items, front.Value.(*indexedEntry).key)
+	}
+}
+
+func (c *cacheIndex) snapshot() []indexedEntry {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	items := make([]indexedEntry, 0, len(c.items))
+	for el := c.order.Front(); el != nil; {
+		next := el.Next()
+		if entry := el.Value.(*indexedEntry); entry.expired(now) {
+			c.order.Remove(el)
+			delete(c.items, entry.key)
+		} else {
+			items = append(items, *entry)
+		}
+		el = next
+	}
+	return items
+}
+
+// DumpCache writes the unexpired responses the client cached as JSON lines, so a short
+// lived process can restore them with LoadCache on its next run. It needs
+// WithCacheIndex.
+func (h *easyRequest) DumpCache(w io.Writer) error {
+	if h.cacheIndex == nil {
+		return errors.New("dumping the cache needs WithCacheIndex")
+	}
+	encoder := json.NewEncoder(w)
+	for _, entry := range h.cacheIndex.snapshot() {
+		key := entry.key
+		cached, err := entry.cache.Get(key)
+		if err != nil {
+			// Evicted or expired by the cache itself
+			continue
+		}
+		response, err := h.decodeCached(cached)
+		if err != nil {
+			continue
+		}
+		dumped := dumpedEntry{Key: key, Expires: entry.expires, Response: toCached(response)}
+		if err := encoder.Encode(dumped); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadCache stores responses written by DumpCache into the client's default cache, set
+// with WithDefaults(WithCache(...)), keeping their remaining expiry.
+func (h *easyRequest) LoadCache(r io.Reader) error {
+	cache := h.applyOptions().cacheObj
+	if cache == nil || cache.fncs == nil {
+		return errors.New("client has no default cache to load into")
+	}
+
+	decoder := json.NewDecoder(r)
+	now := time.Now()
+	for {
+		var entry dumpedEntry
+		if err := decoder.Decode(&entry); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to read cache dump: %w", err)
+		}
+
+		var expiry time.Duration
+		if !entry.Expires.IsZero() {
+			if expiry = entry.Expires.Sub(now); expiry <= 0 {
+				continue
+			}
+		}
+		response, err := fromCached(entry.Response)
+		if err != nil {
+			return fmt.Errorf("failed to read cache dump: %w", err)
+		}
+		encoded, err := h.encodeCached(response)
+		if err != nil {
+			return err
+		}
+		if _, err := cache.fncs.Set(entry.Key, encoded, expiry); err != nil {
+			return err
+		}
+		h.cacheIndex.track(entry.Key, cache.fncs, expiry, "")
+	}
+}
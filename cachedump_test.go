@@ -0,0 +1,117 @@
+package easyrqst
+
+import (
+	"bytes"
+	"container/list"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDumpAndLoadCache(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte("report " + r.URL.Path))
+	}))
+	defer server.Close()
+
+	first := NewHttpClient(server.URL, WithCacheIndex(0), WithDefaults(WithCache(newMapCache(), time.Hour, "v1")))
+	for _, path := range []string{"/a", "/b"} {
+		if _, err := first.Get(WithPath(path)); err != nil {
+			t.Errorf("Error: %v", err)
+			return
+		}
+	}
+
+	var dump bytes.Buffer
+	if err := first.DumpCache(&dump); err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+
+	second := NewHttpClient(server.URL, WithDefaults(WithCache(newMapCache(), time.Hour, "v1")))
+	if err := second.LoadCache(&dump); err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	outcome, err := second.Get(WithPath("/b"))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if !outcome.FromCache || string(outcome.Body) != "report /b" || calls != 2 {
+		t.Errorf("Expected restored cache hit, got %v %s after %v calls", outcome.FromCache, outcome.Body, calls)
+	}
+
+	if err := NewHttpClient(server.URL).LoadCache(&dump); err == nil {
+		t.Errorf("Expected error without a default cache")
+	}
+}
+
+func TestDumpAndLoadCacheVary(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Vary", "Authorization")
+		w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer server.Close()
+
+	first := NewHttpClient(server.URL, WithCacheIndex(0), WithDefaults(WithCache(newMapCache(), time.Hour, "v1")))
+	if _, err := first.Get(WithBasicAuth("neo", "matrix")); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	var dump bytes.Buffer
+	if err := first.DumpCache(&dump); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	second := NewHttpClient(server.URL, WithDefaults(WithCache(newMapCache(), time.Hour, "v1")))
+	if err := second.LoadCache(&dump); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	outcome, err := second.Get(WithBasicAuth("smith", "agent"))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if outcome.FromCache || string(outcome.Body) != "Basic c21pdGg6YWdlbnQ=" {
+		t.Errorf("Expected another user's response not to be served, got %s (from cache %v)", outcome.Body, outcome.FromCache)
+	}
+	outcome, err = second.Get(WithBasicAuth("neo", "matrix"))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if !outcome.FromCache || string(outcome.Body) != "Basic bmVvOm1hdHJpeA==" {
+		t.Errorf("Expected the restored variant to be served, got %s (from cache %v)", outcome.Body, outcome.FromCache)
+	}
+}
+
+func TestCacheIndexBounds(t *testing.T) {
+	cache := newMapCache()
+	index := &cacheIndex{max: 2, order: list.New(), items: make(map[string]*list.Element)}
+	index.track("a", cache, time.Millisecond, "")
+	index.track("b", cache, 0, "")
+	index.track("c", cache, 0, "")
+	index.track("b", cache, 0, "")
+	index.track("d", cache, 0, "")
+
+	var keys []string
+	for _, entry := range index.snapshot() {
+		keys = append(keys, entry.key)
+	}
+	if strings.Join(keys, ",") != "b,d" {
+		t.Errorf("Expected the oldest entries to be dropped, got %v", keys)
+	}
+
+	index.track("e", cache, time.Millisecond, "")
+	time.Sleep(5 * time.Millisecond)
+	if entries := index.snapshot(); len(entries) != 1 || len(index.items) != 1 {
+		t.Errorf("Expected expired entries to be dropped, got %+v", entries)
+	}
+
+	if err := NewHttpClient("http://api.test").DumpCache(io.Discard); err == nil {
+		t.Errorf("Expected dumping without an index to fail")
+	}
+}
@@ -0,0 +1,29 @@
+package easyrqst
+
+type CacheDecision string
+
+const (
+	CacheHit        CacheDecision = "hit"
+	CacheMiss       CacheDecision = "miss"
+	CacheBypass     CacheDecision = "bypass"
+	CacheStore      CacheDecision = "store"
+	CacheError      CacheDecision = "error"
+	CacheInvalidate CacheDecision = "invalidate"
+	// CacheRevalidated is reported when the origin confirmed an expired entry with a 304
+	CacheRevalidated CacheDecision = "revalidated"
+)
+
+// TCacheHook is called with the cache decision taken for every request. err carries
+// the error returned by the cache for misses and failed stores.
+type TCacheHook func(decision CacheDecision, key string, err error)
+
+func WithCacheHook(hook TCacheHook) THttpOption {
+	return func(o *easyRequest) { o.cacheHook = hook }
+}
+
+func (h *easyRequest) cacheDecision(decision CacheDecision, key string, err error) {
+	h.cacheCounters.count(decision)
+	if h.cacheHook != nil {
+		h.cacheHook(decision, key, err)
+	}
+}
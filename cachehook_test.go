@@ -0,0 +1,34 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCacheHook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	var decisions []CacheDecision
+	call := NewHttpClient(server.URL, WithCacheHook(func(decision CacheDecision, key string, err error) {
+		decisions = append(decisions, decision)
+	}))
+
+	cache := WithCache(newMapCache(), time.Minute, "hook")
+	for _, opts := range [][]TReqOption{{cache}, {cache}, {}} {
+		if _, err := call.Get(opts...); err != nil {
+			t.Errorf("Error: %v", err)
+			return
+		}
+	}
+
+	expected := []CacheDecision{CacheMiss, CacheStore, CacheHit, CacheBypass}
+	if !reflect.DeepEqual(decisions, expected) {
+		t.Errorf("Expected %v, got %v", expected, decisions)
+	}
+}
@@ -0,0 +1,46 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCacheKeyFunc(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(r.Header.Get("Accept-Language")))
+	}))
+	defer server.Close()
+
+	cache := newMapCache()
+	call := NewHttpClient(server.URL, WithCacheNamespace("acme"), WithCacheKeyFunc(func(req *http.Request) string {
+		query := req.URL.Query()
+		query.Del("utm_source")
+		return req.URL.Host + req.URL.Path + "?" + query.Encode() + "#" + req.Header.Get("Accept-Language")
+	}))
+	get := func(source, language string) *HttpResponse {
+		resp, err := call.Get(WithPath("docs"), WithQueries(map[string]string{"utm_source": source}),
+			WithHeaders(map[string]string{"Accept-Language": language}), WithCache(cache, time.Minute, "docs"))
+		if err != nil {
+			t.Errorf("Error: %v", err)
+			return &HttpResponse{}
+		}
+		return resp
+	}
+
+	get("mail", "en")
+	if resp := get("ads", "en"); !resp.FromCache || calls != 1 {
+		t.Errorf("Expected the ignored parameter to share the entry, got %v after %d calls", resp.FromCache, calls)
+	}
+	if resp := get("mail", "fr"); resp.FromCache || string(resp.Body) != "fr" || calls != 2 {
+		t.Errorf("Expected the header to split entries, got %v after %d calls", resp.FromCache, calls)
+	}
+
+	host := server.Listener.Addr().String()
+	if _, err := cache.Get("acme_" + host + "/docs?#en"); err != nil {
+		t.Errorf("Expected the custom key under the namespace, got %v", cache.keys())
+	}
+}
@@ -0,0 +1,31 @@
+package easyrqst
+
+import (
+	"math/rand"
+	"time"
+)
+
+// WithCacheableStatuses sets the statuses of responses WithCache stores, 200 and 201
+// by default, e.g. to add 203, 204 or 301. WithNegativeCaching statuses come on top.
+func WithCacheableStatuses(statuses ...int) THttpOption {
+	return func(o *easyRequest) {
+		o.cacheable = make(map[int]bool)
+		for _, status := range statuses {
+			o.cacheable[status] = true
+		}
+	}
+}
+
+// WithCacheJitter shifts the expiry of every cache entry by a random amount of up to
+// fraction of it, either way, so entries stored together don't all expire together.
+func WithCacheJitter(fraction float64) THttpOption {
+	return func(o *easyRequest) { o.jitter = fraction }
+}
+
+func (h *easyRequest) jittered(expiry time.Duration) time.Duration {
+	if h.jitter <= 0 || expiry <= 0 {
+		return expiry
+	}
+	shift := time.Duration((rand.Float64()*2 - 1) * h.jitter * float64(expiry))
+	return max(expiry+shift, time.Millisecond)
+}
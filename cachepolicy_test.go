@@ -0,0 +1,54 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCacheableStatuses(t *testing.T) {
+	calls := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		switch r.URL.Path {
+		case "/empty":
+			w.WriteHeader(http.StatusNoContent)
+		case "/created":
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithCacheableStatuses(http.StatusOK, http.StatusNoContent), WithDefaults(WithCache(newMapCache(), time.Minute, "")))
+	for i := 0; i < 2; i++ {
+		for _, path := range []string{"ok", "empty", "created"} {
+			call.Get(WithPath(path))
+		}
+	}
+	if calls["/ok"] != 1 || calls["/empty"] != 1 || calls["/created"] != 2 {
+		t.Errorf("Expected only the configured statuses cached, got %v", calls)
+	}
+}
+
+func TestCacheJitter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	cache := &expiryCache{mapCache: newMapCache(), expiries: make(map[string]time.Duration)}
+	call := NewHttpClient(server.URL, WithCacheJitter(0.2), WithDefaults(WithCache(cache, 100*time.Second, "")))
+	for i := 0; i < 20; i++ {
+		call.Get(WithQueries(map[string]string{"page": string(rune('a' + i))}))
+	}
+
+	distinct := map[time.Duration]bool{}
+	for key, expiry := range cache.expiries {
+		if expiry < 80*time.Second || expiry > 120*time.Second {
+			t.Errorf("Expected %s to expire within 20%% of 100s, got %v", key, expiry)
+		}
+		distinct[expiry] = true
+	}
+	if len(distinct) < 10 {
+		t.Errorf("Expected spread out expiries, got %v", cache.expiries)
+	}
+}
@@ -0,0 +1,61 @@
+package easyrqst
+
+import "sync/atomic"
+
+// CacheStats counts the cache decisions of a client since it was created. Errors are
+// failed reads, writes and deletes; failed reads also count as misses.
+type CacheStats struct {
+	Hits          int64
+	Misses        int64
+	Bypasses      int64
+	Stores        int64
+	Revalidations int64
+	Invalidations int64
+	Errors        int64
+}
+
+// HitRatio is the share of cache lookups answered from the cache.
+func (s CacheStats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+type cacheCounters struct {
+	hits, misses, bypasses, stores, revalidations, invalidations, errors atomic.Int64
+}
+
+func (c *cacheCounters) count(decision CacheDecision) {
+	switch decision {
+	case CacheHit:
+		c.hits.Add(1)
+	case CacheMiss:
+		c.misses.Add(1)
+	case CacheBypass:
+		c.bypasses.Add(1)
+	case CacheStore:
+		c.stores.Add(1)
+	case CacheRevalidated:
+		c.revalidations.Add(1)
+	case CacheInvalidate:
+		c.invalidations.Add(1)
+	case CacheError:
+		c.errors.Add(1)
+	}
+}
+
+// CacheStats returns the cache counters of the client. WithCacheHook gets every single
+// decision as it is taken.
+func (h *easyRequest) CacheStats() CacheStats {
+	c := &h.cacheCounters
+	return CacheStats{
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		Bypasses:      c.bypasses.Load(),
+		Stores:        c.stores.Load(),
+		Revalidations: c.revalidations.Load(),
+		Invalidations: c.invalidations.Load(),
+		Errors:        c.errors.Load(),
+	}
+}
@@ -0,0 +1,45 @@
+package easyrqst
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type failingCache struct {
+	*mapCache
+}
+
+func (c *failingCache) Set(key string, value any, expiry time.Duration) (any, error) {
+	return nil, errors.New("cache is read-only")
+}
+
+func TestCacheStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	cache := newMapCache()
+	call := NewHttpClient(server.URL, WithCacheIndex(0))
+	cached := WithCache(cache, time.Minute, "")
+	call.Get(cached)
+	call.Get(cached)
+	call.Get(cached)
+	call.Get()
+
+	for key := range cache.items {
+		cache.items[key] = []byte("corrupt")
+	}
+	call.Get(cached)
+	call.Get(WithCache(&failingCache{newMapCache()}, time.Minute, ""))
+	call.InvalidateCache("*")
+
+	expected := CacheStats{Hits: 2, Misses: 3, Bypasses: 1, Stores: 2, Invalidations: 1, Errors: 2}
+	if stats := call.CacheStats(); stats != expected {
+		t.Errorf("Expected %+v, got %+v", expected, stats)
+	}
+	if ratio := call.CacheStats().HitRatio(); ratio != 0.4 {
+		t.Errorf("Expected a 0.4 hit ratio, got %v", ratio)
+	}
+}
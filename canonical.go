@@ -0,0 +1,120 @@
+package easyrqst
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WithCanonicalJSON encodes the JSON payload with CanonicalJSON, so servers that verify a
+// signature over their own canonical encoding see the exact bytes that were signed.
+func WithCanonicalJSON() TReqOption {
+	return func(o *ReqOptions) { o.canonical = true }
+}
+
+// CanonicalJSON encodes v as JSON with object keys sorted at every level, no HTML
+// escaping, no insignificant whitespace and numbers formatted as in RFC 8785: integers
+// as they are, other numbers in their shortest round-trip form.
+func CanonicalJSON(v any) ([]byte, error) {
+	byts, err := marshalJSON(v)
+	if err != nil {
+		return nil, err
+	}
+	var node any
+	decoder := json.NewDecoder(bytes.NewReader(byts))
+	decoder.UseNumber()
+	if err := decoder.Decode(&node); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := writeCanonical(&buf, node); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// marshalJSON is json.Marshal without HTML escaping.
+func marshalJSON(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func writeCanonical(buf *bytes.Buffer, node any) error {
+	switch v := node.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, err := marshalJSON(k)
+			if err != nil {
+				return err
+			}
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := writeCanonical(buf, v[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case []any:
+		buf.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonical(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case json.Number:
+		number, err := canonicalNumber(v)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(number)
+	default:
+		byts, err := marshalJSON(v)
+		if err != nil {
+			return err
+		}
+		buf.Write(byts)
+	}
+	return nil
+}
+
+func canonicalNumber(n json.Number) (string, error) {
+	if !strings.ContainsAny(n.String(), ".eE") {
+		return n.String(), nil
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return "", fmt.Errorf("invalid number %s: %w", n, err)
+	}
+	if f == 0 {
+		return "0", nil
+	}
+	if abs := math.Abs(f); abs >= 1e-6 && abs < 1e21 {
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	}
+	// Exponents carry no leading zeros, 1e-07 is written 1e-7
+	mantissa, exponent, _ := strings.Cut(strconv.FormatFloat(f, 'e', -1, 64), "e")
+	sign, digits := exponent[:1], strings.TrimLeft(exponent[1:], "0")
+	return mantissa + "e" + sign + digits, nil
+}
@@ -0,0 +1,72 @@
+package easyrqst
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	payload := struct {
+		Zeta  string         `json:"zeta"`
+		Alpha map[string]any `json:"alpha"`
+		Items []float64      `json:"items"`
+	}{
+		Zeta:  "<a & b>",
+		Alpha: map[string]any{"y": 1, "x": []any{true, nil}},
+		Items: []float64{1.0, 0.5, 1e21, 1e-7, 123456789012},
+	}
+	byts, err := CanonicalJSON(payload)
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	expected := `{"alpha":{"x":[true,null],"y":1},"items":[1,0.5,1e+21,1e-7,123456789012],"zeta":"<a & b>"}`
+	if string(byts) != expected {
+		t.Errorf("Expected %s, got %s", expected, byts)
+	}
+}
+
+func TestCanonicalJSONSigned(t *testing.T) {
+	key := []byte("secret")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		// The server signs its own canonical encoding of the body it received
+		canonical, err := CanonicalJSON(json.RawMessage(body))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		sum := sha256.Sum256(canonical)
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(strings.Join([]string{r.Method, r.URL.RequestURI(), r.Header.Get("X-Timestamp"), r.Header.Get("X-Nonce"), hex.EncodeToString(sum[:])}, "\n")))
+		if !hmac.Equal([]byte(hex.EncodeToString(mac.Sum(nil))), []byte(r.Header.Get("X-Signature"))) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithSigner(NewHMACSigner(key, HMACConfig{})))
+	payload := struct {
+		Name string  `json:"name"`
+		Cost float64 `json:"cost"`
+	}{Name: "<tag>", Cost: 2.50}
+
+	if outcome, err := call.Post(WithPayload(payload)); err != nil || outcome.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected the default encoding to fail verification, got %v", err)
+	}
+	outcome, err := call.Post(WithPayload(payload), WithCanonicalJSON())
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if outcome.StatusCode != http.StatusOK {
+		t.Errorf("Expected canonical payload to verify, got %v", outcome.StatusCode)
+	}
+}
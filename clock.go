@@ -0,0 +1,70 @@
+package easyrqst
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// TClock returns the current time. Request signers use the client clock so it can be
+// swapped in tests and corrected when it drifts from the server.
+type TClock func() time.Time
+
+type clockObj struct {
+	mu        sync.RWMutex
+	now       TClock
+	tolerance time.Duration
+	offset    time.Duration
+}
+
+func WithClock(clock TClock) THttpOption {
+	return func(o *easyRequest) { o.clock.now = clock }
+}
+
+// WithClockSkewTolerance enables clock drift correction. When a 401 response carries
+// a Date header further than tolerance from the local clock, the offset is remembered
+// and the request is signed and sent once more.
+func WithClockSkewTolerance(tolerance time.Duration) THttpOption {
+	return func(o *easyRequest) { o.clock.tolerance = tolerance }
+}
+
+func (h *easyRequest) now() time.Time {
+	h.clock.mu.RLock()
+	defer h.clock.mu.RUnlock()
+	return h.clock.local().Add(h.clock.offset)
+}
+
+// local is the time before correction; callers hold the lock.
+func (c *clockObj) local() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now()
+}
+
+func (h *easyRequest) adjustSkew(response *HttpResponse) bool {
+	if h.clock.tolerance <= 0 || response.StatusCode != http.StatusUnauthorized || response.FromCache {
+		return false
+	}
+
+	date, err := http.ParseTime(response.Header.Get("Date"))
+	if err != nil {
+		return false
+	}
+
+	h.clock.mu.Lock()
+	defer h.clock.mu.Unlock()
+
+	// The offset is set from the server date rather than added to, so concurrent 401s
+	// for the same drift agree on it instead of correcting it several times.
+	offset := date.Sub(h.clock.local())
+	drift := offset - h.clock.offset
+	if drift < 0 {
+		drift = -drift
+	}
+	if drift <= h.clock.tolerance {
+		return false
+	}
+	h.clock.offset = offset
+	return true
+}
@@ -0,0 +1,70 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClockSkewResign(t *testing.T) {
+	serverTime := time.Now().Add(time.Hour).UTC()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", serverTime.Format(http.TimeFormat))
+		signed, err := http.ParseTime(r.Header.Get("X-Timestamp"))
+		if err != nil || serverTime.Sub(signed) > time.Minute {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	timestamp := func(o *easyRequest) {
+		o.defaults = append(o.defaults, func(r *ReqOptions) {
+			r.editors = append(r.editors, func(req *http.Request) error {
+				req.Header.Set("X-Timestamp", o.now().UTC().Format(http.TimeFormat))
+				return nil
+			})
+		})
+	}
+
+	call := NewHttpClient(server.URL, timestamp, WithClock(time.Now))
+	outcome, err := call.Get()
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if outcome.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected status code 401 without skew tolerance, got %v", outcome.StatusCode)
+	}
+
+	call = NewHttpClient(server.URL, timestamp, WithClockSkewTolerance(time.Minute))
+	outcome, err = call.Get()
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if outcome.StatusCode != http.StatusOK {
+		t.Errorf("Expected status code 200 after re-signing, got %v", outcome.StatusCode)
+	}
+}
+
+func TestClockSkewConcurrent(t *testing.T) {
+	serverTime := time.Now().Add(time.Hour)
+	response := &HttpResponse{StatusCode: http.StatusUnauthorized, Header: http.Header{"Date": {serverTime.UTC().Format(http.TimeFormat)}}}
+
+	call := NewHttpClient("http://localhost", WithClockSkewTolerance(time.Minute)).(*easyRequest)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			call.adjustSkew(response)
+		}()
+	}
+	wg.Wait()
+
+	if drift := call.now().Sub(serverTime); drift < -time.Minute || drift > time.Minute {
+		t.Errorf("Expected the clock to follow the server once, drifted by %v", drift)
+	}
+}
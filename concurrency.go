@@ -0,0 +1,50 @@
+package easyrqst
+
+import (
+	"context"
+	"fmt"
+)
+
+// ConcurrencyLimitError is returned when WithRejectWhenBusy is set and the client
+// already has Limit requests in flight.
+type ConcurrencyLimitError struct {
+	Limit int
+}
+
+func (e *ConcurrencyLimitError) Error() string {
+	return fmt.Sprintf("too many concurrent requests, limit is %d", e.Limit)
+}
+
+// WithMaxConcurrent caps the number of requests in flight. Extra requests wait for a
+// slot until their context is done. Cache hits don't take a slot.
+func WithMaxConcurrent(n int) THttpOption {
+	return func(o *easyRequest) { o.slots = make(chan struct{}, n) }
+}
+
+// WithRejectWhenBusy fails requests over the WithMaxConcurrent limit right away with a
+// *ConcurrencyLimitError instead of queuing them.
+func WithRejectWhenBusy() THttpOption {
+	return func(o *easyRequest) { o.rejectWhenBusy = true }
+}
+
+// acquire takes a slot; the returned function gives it back.
+func (h *easyRequest) acquire(ctx context.Context) (func(), error) {
+	if h.slots == nil {
+		return func() {}, nil
+	}
+	release := func() { <-h.slots }
+	if h.rejectWhenBusy {
+		select {
+		case h.slots <- struct{}{}:
+			return release, nil
+		default:
+			return nil, &ConcurrencyLimitError{Limit: cap(h.slots)}
+		}
+	}
+	select {
+	case h.slots <- struct{}{}:
+		return release, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,69 @@
+package easyrqst
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMaxConcurrent(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		inFlight.Add(-1)
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithMaxConcurrent(2))
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := call.Get(); err != nil {
+				t.Errorf("Error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if peak.Load() != 2 {
+		t.Errorf("Expected at most 2 requests in flight, got %v", peak.Load())
+	}
+}
+
+func TestMaxConcurrentReject(t *testing.T) {
+	block := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { <-block }))
+	defer server.Close()
+	defer close(block)
+
+	call := NewHttpClient(server.URL, WithMaxConcurrent(1), WithRejectWhenBusy())
+	go call.Get()
+	time.Sleep(20 * time.Millisecond)
+
+	var limitErr *ConcurrencyLimitError
+	if _, err := call.Get(); !errors.As(err, &limitErr) || limitErr.Limit != 1 {
+		t.Errorf("Expected *ConcurrencyLimitError, got %v", err)
+	}
+
+	queued := NewHttpClient(server.URL, WithMaxConcurrent(1))
+	go queued.Get()
+	time.Sleep(20 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := queued.Get(WithContext(ctx)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected queued request to give up with its context, got %v", err)
+	}
+}
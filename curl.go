@@ -0,0 +1,110 @@
+package easyrqst
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type curlLogger struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// WithCurlLogger writes every request to w as a curl command reproducing it.
+func WithCurlLogger(w io.Writer) THttpOption {
+	return func(o *easyRequest) { o.curl = &curlLogger{w: w} }
+}
+
+func (l *curlLogger) log(req *http.Request) {
+	if l == nil {
+		return
+	}
+	command, err := AsCurl(req)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err != nil {
+		fmt.Fprintf(l.w, "# %v\n", err)
+		return
+	}
+	fmt.Fprintln(l.w, command)
+}
+
+// AsCurl renders req as a copy-pasteable curl command. Multipart bodies are summarized
+// as -F fields, with files referenced by name.
+func AsCurl(req *http.Request) (string, error) {
+	var body []byte
+	if req.GetBody != nil {
+		reader, err := req.GetBody()
+		if err != nil {
+			return "", err
+		}
+		defer reader.Close()
+		if body, err = io.ReadAll(reader); err != nil {
+			return "", err
+		}
+	}
+
+	parts := []string{"curl", "-X", req.Method, shellQuote(req.URL.String())}
+	mediaType, params, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	multipartBody := mediaType == "multipart/form-data" && len(body) > 0
+
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		// curl picks its own boundary for -F fields
+		if multipartBody && name == "Content-Type" {
+			continue
+		}
+		for _, value := range req.Header[name] {
+			parts = append(parts, "-H", shellQuote(name+": "+value))
+		}
+	}
+
+	if multipartBody {
+		fields, err := curlFormFields(body, params["boundary"])
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, fields...)
+	} else if len(body) > 0 {
+		parts = append(parts, "--data-raw", shellQuote(string(body)))
+	}
+	return strings.Join(parts, " "), nil
+}
+
+func curlFormFields(body []byte, boundary string) ([]string, error) {
+	var fields []string
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return fields, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read multipart body: %w", err)
+		}
+		if part.FileName() != "" {
+			fields = append(fields, "-F", shellQuote(part.FormName()+"=@"+part.FileName()))
+			continue
+		}
+		value, err := io.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, "-F", shellQuote(part.FormName()+"="+string(value)))
+	}
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
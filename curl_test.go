@@ -0,0 +1,55 @@
+package easyrqst
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAsCurl(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "https://api.example.com/users?notify=true", strings.NewReader(`{"name":"O'Neil"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer abc")
+
+	command, err := AsCurl(req)
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	expected := `curl -X POST 'https://api.example.com/users?notify=true' -H 'Authorization: Bearer abc' -H 'Content-Type: application/json' --data-raw '{"name":"O'\''Neil"}'`
+	if command != expected {
+		t.Errorf("Expected %s, got %s", expected, command)
+	}
+}
+
+func TestCurlLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	file := filepath.Join(t.TempDir(), "avatar.png")
+	os.WriteFile(file, []byte("png"), 0o600)
+
+	var buf bytes.Buffer
+	call := NewHttpClient(server.URL, WithCurlLogger(&buf))
+	_, err := call.Post(
+		WithHeaders(map[string]string{"Content-Type": "multipart/form-data"}),
+		WithPayload(map[string]string{"name": "neo"}),
+		WithFiles(map[string]string{"avatar": file}),
+	)
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+
+	command := buf.String()
+	if !strings.HasPrefix(command, "curl -X POST '"+server.URL+"'") || !strings.Contains(command, "-F 'name=neo'") || !strings.Contains(command, "-F 'avatar=@avatar.png'") {
+		t.Errorf("Expected multipart fields summarized, got %s", command)
+	}
+	if strings.Contains(command, "boundary") {
+		t.Errorf("Expected the multipart content type to be left to curl, got %s", command)
+	}
+}
@@ -0,0 +1,281 @@
+package easyrqst
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const debugHistory = 100
+
+// debugPhase is one bar of the timing waterfall, relative to the start of the request.
+type debugPhase struct {
+	Name     string
+	Offset   time.Duration
+	Duration time.Duration
+}
+
+type debugEntry struct {
+	Time           time.Time
+	Method         string
+	URL            string
+	StatusCode     int
+	Err            string
+	RequestHeader  http.Header
+	ResponseHeader http.Header
+	BodySize       int
+	Total          time.Duration
+	Phases         []debugPhase
+}
+
+type debugRecorder struct {
+	mu      sync.Mutex
+	entries []debugEntry
+}
+
+func (recorder *debugRecorder) add(entry debugEntry) {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	recorder.entries = append(recorder.entries, entry)
+	if len(recorder.entries) > debugHistory {
+		recorder.entries = recorder.entries[len(recorder.entries)-debugHistory:]
+	}
+}
+
+// recent returns the recorded entries, newest first.
+func (recorder *debugRecorder) recent() []debugEntry {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	entries := make([]debugEntry, len(recorder.entries))
+	for i, entry := range recorder.entries {
+		entries[len(entries)-1-i] = entry
+	}
+	return entries
+}
+
+// traceTimings collects connection milestones of the last attempt of a request.
+type traceTimings struct {
+	mu                     sync.Mutex
+	start                  time.Time
+	attemptStart           time.Time
+	dnsStart, dnsDone      time.Time
+	connectStart, connDone time.Time
+	tlsStart, tlsDone      time.Time
+	wroteRequest           time.Time
+	firstByte              time.Time
+}
+
+func (t *traceTimings) mark(at *time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	*at = time.Now()
+}
+
+// reset forgets the milestones of a previous attempt.
+func (t *traceTimings) reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.attemptStart = time.Now()
+	t.dnsStart, t.dnsDone = time.Time{}, time.Time{}
+	t.connectStart, t.connDone = time.Time{}, time.Time{}
+	t.tlsStart, t.tlsDone = time.Time{}, time.Time{}
+	t.wroteRequest, t.firstByte = time.Time{}, time.Time{}
+}
+
+func (t *traceTimings) trace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		GetConn:              func(string) { t.reset() },
+		DNSStart:             func(httptrace.DNSStartInfo) { t.mark(&t.dnsStart) },
+		DNSDone:              func(httptrace.DNSDoneInfo) { t.mark(&t.dnsDone) },
+		ConnectStart:         func(string, string) { t.mark(&t.connectStart) },
+		ConnectDone:          func(string, string, error) { t.mark(&t.connDone) },
+		TLSHandshakeStart:    func() { t.mark(&t.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { t.mark(&t.tlsDone) },
+		WroteRequest:         func(httptrace.WroteRequestInfo) { t.mark(&t.wroteRequest) },
+		GotFirstResponseByte: func() { t.mark(&t.firstByte) },
+	}
+}
+
+func (t *traceTimings) phases(end time.Time) []debugPhase {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var phases []debugPhase
+	add := func(name string, from, to time.Time) {
+		if !from.IsZero() && !to.IsZero() {
+			phases = append(phases, debugPhase{Name: name, Offset: from.Sub(t.start), Duration: to.Sub(from)})
+		}
+	}
+	add("dns", t.dnsStart, t.dnsDone)
+	add("connect", t.connectStart, t.connDone)
+	add("tls", t.tlsStart, t.tlsDone)
+	add("wait", t.wroteRequest, t.firstByte)
+	add("download", t.firstByte, end)
+	return phases
+}
+
+var sensitiveHeaders = []string{"authorization", "cookie", "token", "secret", "key", "password", "signature"}
+
+// redactHeader masks values of headers that usually carry credentials, and of those
+// whose name contains any of extra.
+func redactHeader(header http.Header, extra ...string) http.Header {
+	redacted := make(http.Header, len(header))
+	for name, values := range header {
+		if sensitive(name, extra) {
+			values = []string{"REDACTED"}
+		}
+		redacted[name] = values
+	}
+	return redacted
+}
+
+// redactURL masks the password of u and the values of query parameters that usually
+// carry credentials, and of those whose name contains any of extra.
+func redactURL(u *url.URL, extra ...string) string {
+	redacted := *u
+	query := u.Query()
+	masked := false
+	for name := range query {
+		if sensitive(name, extra) {
+			query[name] = []string{"REDACTED"}
+			masked = true
+		}
+	}
+	if masked {
+		redacted.RawQuery = query.Encode()
+	}
+	return redacted.Redacted()
+}
+
+func sensitive(name string, extra []string) bool {
+	lower := strings.ToLower(name)
+	for _, s := range append(sensitiveHeaders, extra...) {
+		if strings.Contains(lower, strings.ToLower(s)) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithRedactedQueryParams masks the query parameters whose name contains any of names
+// on the debug page and in traffic logs, on top of API keys set WithAPIKey InQuery and parameters named
+// like credentials (token, key, secret, ...).
+func WithRedactedQueryParams(names ...string) THttpOption {
+	return func(o *easyRequest) { o.redactedParams = append(o.redactedParams, names...) }
+}
+
+// secretParams returns the query parameters to mask for a request made with options.
+func (h *easyRequest) secretParams(options *ReqOptions) []string {
+	return append(h.redactedParams[:len(h.redactedParams):len(h.redactedParams)], options.secretParams...)
+}
+
+// secretHeaders are the headers to mask on top of those named like credentials: API
+// keys set WithAPIKey InHeader and the headers of the request signer.
+func (h *easyRequest) secretHeaders(options *ReqOptions) []string {
+	secret := options.secretHeaders[:len(options.secretHeaders):len(options.secretHeaders)]
+	if signer, ok := h.signer.(headerSigner); ok {
+		secret = append(secret, signer.signatureHeaders()...)
+	}
+	return secret
+}
+
+// recordDebug traces req when the debug page is being served. The returned function
+// records the outcome of the request.
+func (h *easyRequest) recordDebug(req *http.Request, options *ReqOptions) (*http.Request, func(statusCode int, header http.Header, size int, err error)) {
+	recorder := h.debug.Load()
+	if recorder == nil {
+		return req, func(int, http.Header, int, error) {}
+	}
+
+	timings := &traceTimings{start: time.Now()}
+	entry := debugEntry{Time: timings.start, Method: req.Method, URL: redactURL(req.URL, h.secretParams(options)...), RequestHeader: redactHeader(req.Header, h.secretHeaders(options)...)}
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), timings.trace()))
+
+	return req, func(statusCode int, header http.Header, size int, err error) {
+		if !h.sampling.keep(statusCode, err) {
+			return
+		}
+		end := time.Now()
+		entry.StatusCode = statusCode
+		entry.ResponseHeader = redactHeader(header, h.secretHeaders(options)...)
+		entry.BodySize = size
+		if err != nil {
+			entry.Err = err.Error()
+		}
+		entry.Total = end.Sub(timings.start)
+		entry.Phases = timings.phases(end)
+		recorder.add(entry)
+	}
+}
+
+// ServeDebug records the requests made from now on and serves them on addr: a web page
+// with headers (credentials redacted) and timing waterfalls at /, and the raw data at
+// /requests.json. It blocks like http.ListenAndServe and is meant for local development.
+func (h *easyRequest) ServeDebug(addr string) error {
+	h.debug.CompareAndSwap(nil, &debugRecorder{})
+	return http.ListenAndServe(addr, h.debug.Load().handler())
+}
+
+func (recorder *debugRecorder) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/requests.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(recorder.recent())
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		debugPage.Execute(w, recorder.recent())
+	})
+	return mux
+}
+
+var debugPage = template.Must(template.New("debug").Funcs(template.FuncMap{
+	"percent": func(part, total time.Duration) string {
+		if total <= 0 {
+			return "0"
+		}
+		return fmt.Sprintf("%.2f", float64(part)*100/float64(total))
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<title>easyrqst debug</title>
+<style>
+body { font-family: sans-serif; font-size: 13px; }
+table { border-collapse: collapse; width: 100%; }
+td, th { border-bottom: 1px solid #ddd; padding: 4px; text-align: left; vertical-align: top; }
+.waterfall { position: relative; width: 300px; height: 14px; background: #f4f4f4; }
+.phase { position: absolute; height: 14px; }
+.dns { background: #7cb5ec; } .connect { background: #f7a35c; } .tls { background: #8085e9; }
+.wait { background: #90ed7d; } .download { background: #434348; }
+.error { color: #c00; }
+</style>
+</head>
+<body>
+<h1>Recent requests</h1>
+<table>
+<tr><th>Time</th><th>Request</th><th>Status</th><th>Size</th><th>Total</th><th>Timing</th></tr>
+{{range .}}
+<tr>
+<td>{{.Time.Format "15:04:05.000"}}</td>
+<td><details><summary>{{.Method}} {{.URL}}</summary>
+<pre>{{range $k, $v := .RequestHeader}}{{$k}}: {{$v}}
+{{end}}</pre><pre>{{range $k, $v := .ResponseHeader}}{{$k}}: {{$v}}
+{{end}}</pre></details></td>
+<td>{{if .Err}}<span class="error">{{.Err}}</span>{{else}}{{.StatusCode}}{{end}}</td>
+<td>{{.BodySize}}</td>
+<td>{{.Total}}</td>
+<td><div class="waterfall">{{$total := .Total}}{{range .Phases}}<div class="phase {{.Name}}" title="{{.Name}} {{.Duration}}" style="left: {{percent .Offset $total}}%; width: {{percent .Duration $total}}%"></div>{{end}}</div></td>
+</tr>
+{{end}}
+</table>
+</body>
+</html>
+`))
@@ -0,0 +1,77 @@
+package easyrqst
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDebugRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithClientBasicAuth("neo", "matrix")).(*easyRequest)
+	if _, err := call.Get(); err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	recorder := &debugRecorder{}
+	call.debug.Store(recorder)
+	if _, err := call.Get(WithPath("/users")); err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+
+	rec := httptest.NewRecorder()
+	recorder.handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/requests.json", nil))
+	var entries []debugEntry
+	if err := json.Unmarshal(rec.Body.Bytes(), &entries); err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if len(entries) != 1 || entries[0].URL != server.URL+"/users" || entries[0].StatusCode != http.StatusOK || entries[0].BodySize != 2 {
+		t.Errorf("Expected only the request made after enabling to be recorded, got %+v", entries)
+		return
+	}
+	if entries[0].RequestHeader.Get("Authorization") != "REDACTED" {
+		t.Errorf("Expected credentials to be redacted, got %v", entries[0].RequestHeader)
+	}
+	if len(entries[0].Phases) == 0 {
+		t.Errorf("Expected timing phases")
+	}
+
+	rec = httptest.NewRecorder()
+	recorder.handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if page := rec.Body.String(); !strings.Contains(page, "GET "+server.URL+"/users") || strings.Contains(page, "bmVvOm1hdHJpeA") {
+		t.Errorf("Expected redacted request on the debug page, got %s", page)
+	}
+}
+
+func TestDebugRecorderQueryRedaction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithRedactedQueryParams("tenant")).(*easyRequest)
+	recorder := &debugRecorder{}
+	call.debug.Store(recorder)
+	query := WithQueries(map[string]string{"page": "2", "access_token": "t0k3n", "tenant": "acme"})
+	if _, err := call.Get(query, WithAPIKey("k", "s3cr3t", InQuery), WithAPIKey("X-Auth", "h34d3r", InHeader)); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	recorder.handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/requests.json", nil))
+	body := rec.Body.String()
+	for _, secret := range []string{"s3cr3t", "t0k3n", "acme", "h34d3r"} {
+		if strings.Contains(body, secret) {
+			t.Errorf("Expected %s to be redacted, got %s", secret, body)
+		}
+	}
+	if !strings.Contains(body, "page=2") {
+		t.Errorf("Expected other parameters to be kept, got %s", body)
+	}
+}
@@ -0,0 +1,119 @@
+package easyrqst
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/json"
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+type decodedEntry struct {
+	key   string
+	typ   reflect.Type
+	sum   [sha256.Size]byte
+	value any
+}
+
+// decodedCache keeps decoded values of cached responses next to the raw cache entry.
+// An entry is only reused while the raw body it was decoded from is unchanged.
+type decodedCache struct {
+	mu      sync.Mutex
+	max     int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+// WithDecodedCache memoizes Decode results for cached responses, so hot cache hits skip
+// JSON parsing. Decoded values are shared between callers and must not be modified.
+func WithDecodedCache(maxEntries int) THttpOption {
+	return func(o *easyRequest) {
+		o.decoded = &decodedCache{max: maxEntries, order: list.New(), entries: make(map[string]*list.Element)}
+	}
+}
+
+// Decode unmarshals the body of the response into T, as XML when the Content-Type says
+// so and as JSON otherwise.
+func Decode[T any](resp *HttpResponse) (T, error) {
+	var result T
+	typ := reflect.TypeOf(&result).Elem()
+	cache := resp.decoded
+	if cache == nil || resp.cacheKey == "" {
+		err := resp.unmarshal(&result, typ)
+		return result, err
+	}
+
+	key := resp.cacheKey
+	sum := sha256.Sum256(resp.Body)
+	if value, ok := cache.get(key, typ, sum); ok {
+		return value.(T), nil
+	}
+	if err := resp.unmarshal(&result, typ); err != nil {
+		return result, err
+	}
+	cache.set(&decodedEntry{key: key, typ: typ, sum: sum, value: result})
+	return result, nil
+}
+
+func (h *HttpResponse) unmarshal(v any, typ reflect.Type) error {
+	if strings.Contains(h.Header.Get("Content-Type"), "xml") {
+		if h.strict {
+			if err := checkStrictXML(h.Body, typ); err != nil {
+				return err
+			}
+		}
+		return xml.Unmarshal(h.Body, v)
+	}
+	if h.strict {
+		if err := checkStrictJSON(h.Body, typ); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(h.Body, v)
+}
+
+func (c *decodedCache) get(key string, typ reflect.Type, sum [sha256.Size]byte) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*decodedEntry)
+	if entry.typ != typ || entry.sum != sum {
+		c.order.Remove(el)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return entry.value, true
+}
+
+func (c *decodedCache) set(entry *decodedEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[entry.key]; ok {
+		c.order.Remove(el)
+	}
+	c.entries[entry.key] = c.order.PushFront(entry)
+	for c.max > 0 && c.order.Len() > c.max {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*decodedEntry).key)
+	}
+}
+
+// forget drops decoded values of a raw cache entry.
+func (c *decodedCache) forget(cacheKey string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[cacheKey]; ok {
+		c.order.Remove(el)
+		delete(c.entries, cacheKey)
+	}
+}
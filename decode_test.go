@@ -0,0 +1,58 @@
+package easyrqst
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type decodeItem struct {
+	Version int `json:"version"`
+}
+
+func TestDecodedCache(t *testing.T) {
+	version := 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"version":%d}`, version)
+	}))
+	defer server.Close()
+
+	raw := newMapCache()
+	call := NewHttpClient(server.URL, WithDecodedCache(10))
+	cache := WithCache(raw, time.Minute, "decoded")
+
+	decode := func() *decodeItem {
+		t.Helper()
+		outcome, err := call.Get(cache)
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+		item, err := Decode[*decodeItem](outcome)
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+		return item
+	}
+
+	first := decode()
+	second := decode()
+	if first != second {
+		t.Errorf("Expected cached hit to reuse the decoded value")
+	}
+
+	version = 2
+	raw.Delete(raw.keys()[0])
+	third := decode()
+	if third == first || third.Version != 2 {
+		t.Errorf("Expected decoded value to follow the raw entry, got %+v", third)
+	}
+}
+
+func TestDecodeWithoutCache(t *testing.T) {
+	item, err := Decode[decodeItem](&HttpResponse{Body: []byte(`{"version":3}`)})
+	if err != nil || item.Version != 3 {
+		t.Errorf("Unexpected result %+v, %v", item, err)
+	}
+}
@@ -0,0 +1,52 @@
+package easyrqst
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"sync"
+)
+
+type dumper struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// WithDump writes every attempt to w in wire format, as httputil.DumpRequestOut and
+// httputil.DumpResponse do: the final URL, the headers and the body actually sent,
+// then the response. Responses are read into memory to be dumped, streams included.
+func WithDump(w io.Writer) THttpOption {
+	return func(o *easyRequest) { o.dump = &dumper{w: w} }
+}
+
+func (d *dumper) request(req *http.Request) {
+	if d == nil {
+		return
+	}
+	dump, err := httputil.DumpRequestOut(req, true)
+	d.write(dump, err)
+}
+
+func (d *dumper) response(resp *http.Response, err error) {
+	if d == nil {
+		return
+	}
+	if err != nil {
+		d.write(nil, err)
+		return
+	}
+	dump, err := httputil.DumpResponse(resp, true)
+	d.write(dump, err)
+}
+
+func (d *dumper) write(dump []byte, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err != nil {
+		fmt.Fprintf(d.w, "error: %v\n\n", err)
+		return
+	}
+	d.w.Write(dump)
+	d.w.Write([]byte("\n\n"))
+}
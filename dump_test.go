@@ -0,0 +1,42 @@
+package easyrqst
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDump(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Served", "yes")
+		w.Write([]byte("created"))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	call := NewHttpClient(server.URL, WithDump(&buf))
+	outcome, err := call.Post(WithPath("users"), WithQueries(map[string]string{"notify": "true"}), WithPayload(map[string]string{"name": "neo"}))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if string(outcome.Body) != "created" {
+		t.Errorf("Expected the response body to survive the dump, got %s", outcome.Body)
+	}
+
+	dump := strings.ReplaceAll(buf.String(), "\r\n", "\n")
+	for _, expected := range []string{
+		"POST /users?notify=true HTTP/1.1\n",
+		"Content-Type: application/json\n",
+		"\n\n{\"name\":\"neo\"}",
+		"HTTP/1.1 200 OK\n",
+		"X-Served: yes\n",
+		"\n\ncreated",
+	} {
+		if !strings.Contains(dump, expected) {
+			t.Errorf("Expected %q in dump:\n%s", expected, dump)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package easyrqsttest
+
+import (
+	"encoding/json"
+	"github.com/captain-bugs/easyrqst"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// AssertStatus fails the test unless resp has status.
+func AssertStatus(t testing.TB, resp *easyrqst.HttpResponse, status int) {
+	t.Helper()
+	if !present(t, resp) {
+		return
+	}
+	if resp.StatusCode != status {
+		t.Errorf("Expected status %d, got %d with body %s", status, resp.StatusCode, resp.Body)
+	}
+}
+
+// AssertHeader fails the test unless the key header of resp is value.
+func AssertHeader(t testing.TB, resp *easyrqst.HttpResponse, key, value string) {
+	t.Helper()
+	if !present(t, resp) {
+		return
+	}
+	if values, ok := resp.Header[http.CanonicalHeaderKey(key)]; !ok {
+		t.Errorf("Expected header %s: %s, got no such header", key, value)
+	} else if actual := resp.Header.Get(key); actual != value {
+		t.Errorf("Expected header %s: %s, got %s", key, value, strings.Join(values, ", "))
+	}
+}
+
+// AssertJSONBody fails the test unless the body of resp is JSON equal to expected,
+// ignoring formatting and key order. Differences are reported as a line diff.
+func AssertJSONBody(t testing.TB, resp *easyrqst.HttpResponse, expected any) {
+	t.Helper()
+	if !present(t, resp) {
+		return
+	}
+	want, err := normalizeJSON(expected)
+	if err != nil {
+		t.Errorf("Expected body can't be encoded as JSON: %v", err)
+		return
+	}
+	var got any
+	if err := json.Unmarshal(resp.Body, &got); err != nil {
+		t.Errorf("Expected a JSON body, got %q (%v)", resp.Body, err)
+		return
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("Unexpected JSON body (-expected +actual):\n%s", diffLines(indentJSON(want), indentJSON(got)))
+	}
+}
+
+// AssertFromCache fails the test unless resp was served from the cache.
+func AssertFromCache(t testing.TB, resp *easyrqst.HttpResponse) {
+	t.Helper()
+	if present(t, resp) && !resp.FromCache {
+		t.Errorf("Expected %s %s to be served from the cache", resp.Method(), resp.FinalURL)
+	}
+}
+
+// AssertNotFromCache fails the test if resp was served from the cache.
+func AssertNotFromCache(t testing.TB, resp *easyrqst.HttpResponse) {
+	t.Helper()
+	if present(t, resp) && resp.FromCache {
+		t.Errorf("Expected %s %s not to be served from the cache (key %s)", resp.Method(), resp.FinalURL, resp.CacheKey())
+	}
+}
+
+func present(t testing.TB, resp *easyrqst.HttpResponse) bool {
+	t.Helper()
+	if resp == nil {
+		t.Errorf("Expected a response, got nil")
+		return false
+	}
+	return true
+}
+
+func indentJSON(v any) []string {
+	byts, _ := json.MarshalIndent(v, "", "  ")
+	return strings.Split(string(byts), "\n")
+}
+
+// diffLines returns the lines of expected and actual, prefixed with - when only in
+// expected, + when only in actual and a space when in both.
+func diffLines(expected, actual []string) string {
+	// common[i][j] is the length of the longest common subsequence of expected[i:] and actual[j:]
+	common := make([][]int, len(expected)+1)
+	for i := range common {
+		common[i] = make([]int, len(actual)+1)
+	}
+	for i := len(expected) - 1; i >= 0; i-- {
+		for j := len(actual) - 1; j >= 0; j-- {
+			if expected[i] == actual[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	var diff strings.Builder
+	i, j := 0, 0
+	for i < len(expected) || j < len(actual) {
+		switch {
+		case i < len(expected) && j < len(actual) && expected[i] == actual[j]:
+			diff.WriteString("  " + expected[i] + "\n")
+			i, j = i+1, j+1
+		case j == len(actual) || (i < len(expected) && common[i+1][j] >= common[i][j+1]):
+			diff.WriteString("- " + expected[i] + "\n")
+			i++
+		default:
+			diff.WriteString("+ " + actual[j] + "\n")
+			j++
+		}
+	}
+	return diff.String()
+}
@@ -0,0 +1,97 @@
+package easyrqsttest
+
+import (
+	"fmt"
+	"github.com/captain-bugs/easyrqst"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder collects the failures reported to it instead of failing the test.
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+type memoryCache struct {
+	mu    sync.Mutex
+	items map[string]any
+}
+
+func (c *memoryCache) Get(key string) (any, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if value, ok := c.items[key]; ok {
+		return value, nil
+	}
+	return nil, fmt.Errorf("%s not found", key)
+}
+
+func (c *memoryCache) Set(key string, value any, expiry time.Duration) (any, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items[key] = value
+	return value, nil
+}
+
+func (c *memoryCache) Delete(key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.items, key)
+	return nil
+}
+
+func TestAssertions(t *testing.T) {
+	mock := NewMockTransport()
+	mock.On(http.MethodGet, "/user").ReplyJSON(http.StatusOK, map[string]any{"name": "neo", "roles": []string{"admin", "ops"}}).Header("X-Version", "2")
+
+	call := easyrqst.NewHttpClient("http://api.test", mock.Option(), easyrqst.WithRetry(0))
+	cached := easyrqst.WithCache(&memoryCache{items: make(map[string]any)}, time.Minute, "")
+	first, err := call.Get(easyrqst.WithPath("user"), cached)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	second, _ := call.Get(easyrqst.WithPath("user"), cached)
+
+	AssertStatus(t, first, http.StatusOK)
+	AssertHeader(t, first, "x-version", "2")
+	AssertJSONBody(t, first, map[string]any{"roles": []string{"admin", "ops"}, "name": "neo"})
+	AssertNotFromCache(t, first)
+	AssertFromCache(t, second)
+
+	r := &recorder{TB: t}
+	AssertStatus(r, first, http.StatusCreated)
+	AssertHeader(r, first, "X-Version", "3")
+	AssertHeader(r, first, "X-Missing", "1")
+	AssertJSONBody(r, first, map[string]any{"name": "trinity", "roles": []string{"admin", "ops"}})
+	AssertFromCache(r, first)
+	AssertNotFromCache(r, second)
+	AssertStatus(r, nil, http.StatusOK)
+	if len(r.failures) != 7 {
+		t.Fatalf("Expected 7 failures, got %d: %q", len(r.failures), r.failures)
+	}
+
+	expected := []string{
+		"Expected status 201, got 200",
+		"Expected header X-Version: 3, got 2",
+		"Expected header X-Missing: 1, got no such header",
+		"-   \"name\": \"trinity\",\n+   \"name\": \"neo\",\n    \"roles\": [",
+		"Expected GET http://api.test/user to be served from the cache",
+		"not to be served from the cache",
+		"Expected a response, got nil",
+	}
+	for i, want := range expected {
+		if !strings.Contains(r.failures[i], want) {
+			t.Errorf("Expected failure %d to contain %q, got %q", i, want, r.failures[i])
+		}
+	}
+}
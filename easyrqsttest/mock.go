@@ -0,0 +1,269 @@
+// Package easyrqsttest provides test doubles for code built on easyrqst.
+package easyrqsttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/captain-bugs/easyrqst"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Call is a request received by a MockTransport.
+type Call struct {
+	Method string
+	Path   string
+	Query  map[string][]string
+	Header http.Header
+	Body   []byte
+}
+
+// Rule matches requests and describes the canned reply. Rules are built with
+// MockTransport.On and match in registration order.
+type Rule struct {
+	method  string
+	path    string
+	query   map[string]string
+	body    []func([]byte) bool
+	status  int
+	header  http.Header
+	reply   []byte
+	err     error
+	delay   time.Duration
+	times   int
+	matched int
+}
+
+// MockTransport is an http.RoundTripper answering requests from registered rules, so
+// clients can be tested without a server. Plug it in with Option.
+type MockTransport struct {
+	mu    sync.Mutex
+	rules []*Rule
+	calls []Call
+}
+
+func NewMockTransport() *MockTransport {
+	return &MockTransport{}
+}
+
+// Option makes a client send its requests to the mock.
+func (m *MockTransport) Option() easyrqst.THttpOption {
+	return easyrqst.WithRoundTripper(m)
+}
+
+// On registers a rule for method and path, replying 200 with an empty body until
+// configured otherwise. An empty method matches any method.
+func (m *MockTransport) On(method, path string) *Rule {
+	rule := &Rule{method: method, path: "/" + strings.TrimPrefix(path, "/"), status: http.StatusOK, header: http.Header{}}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rules = append(m.rules, rule)
+	return rule
+}
+
+// Query requires the query parameter key to equal value.
+func (r *Rule) Query(key, value string) *Rule {
+	if r.query == nil {
+		r.query = make(map[string]string)
+	}
+	r.query[key] = value
+	return r
+}
+
+// Body requires match to accept the request body.
+func (r *Rule) Body(match func(body []byte) bool) *Rule {
+	r.body = append(r.body, match)
+	return r
+}
+
+// BodyContains requires the request body to contain s.
+func (r *Rule) BodyContains(s string) *Rule {
+	return r.Body(func(body []byte) bool { return bytes.Contains(body, []byte(s)) })
+}
+
+// JSONBody requires the request body to be JSON equal to v, ignoring formatting and
+// key order.
+func (r *Rule) JSONBody(v any) *Rule {
+	expected, err := normalizeJSON(v)
+	return r.Body(func(body []byte) bool {
+		var actual any
+		return err == nil && json.Unmarshal(body, &actual) == nil && reflect.DeepEqual(expected, actual)
+	})
+}
+
+// Reply answers with status and body.
+func (r *Rule) Reply(status int, body string) *Rule {
+	r.status = status
+	r.reply = []byte(body)
+	return r
+}
+
+// ReplyJSON answers with status and v encoded as JSON.
+func (r *Rule) ReplyJSON(status int, v any) *Rule {
+	byts, err := json.Marshal(v)
+	if err != nil {
+		r.err = fmt.Errorf("failed to encode mock reply: %w", err)
+		return r
+	}
+	r.header.Set("Content-Type", "application/json")
+	return r.Reply(status, string(byts))
+}
+
+// Header adds a response header.
+func (r *Rule) Header(key, value string) *Rule {
+	r.header.Add(key, value)
+	return r
+}
+
+// Fail answers with a transport error instead of a response.
+func (r *Rule) Fail(err error) *Rule {
+	r.err = err
+	return r
+}
+
+// Delay waits before answering, or until the request is canceled.
+func (r *Rule) Delay(d time.Duration) *Rule {
+	r.delay = d
+	return r
+}
+
+// Times limits the rule to n matches, so the next matching rule answers afterwards.
+func (r *Rule) Times(n int) *Rule {
+	r.times = n
+	return r
+}
+
+func (r *Rule) matches(call Call) bool {
+	if r.times > 0 && r.matched >= r.times {
+		return false
+	}
+	if r.method != "" && r.method != call.Method || r.path != call.Path {
+		return false
+	}
+	for key, value := range r.query {
+		if values := call.Query[key]; len(values) == 0 || values[0] != value {
+			return false
+		}
+	}
+	for _, match := range r.body {
+		if !match(call.Body) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *MockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	call := Call{Method: req.Method, Path: req.URL.Path, Query: req.URL.Query(), Header: req.Header.Clone()}
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		call.Body = body
+	}
+
+	m.mu.Lock()
+	m.calls = append(m.calls, call)
+	var rule *Rule
+	for _, candidate := range m.rules {
+		if candidate.matches(call) {
+			rule = candidate
+			rule.matched++
+			break
+		}
+	}
+	m.mu.Unlock()
+
+	if rule == nil {
+		return nil, fmt.Errorf("no mock rule matches %s %s", call.Method, req.URL.RequestURI())
+	}
+	if rule.delay > 0 {
+		timer := time.NewTimer(rule.delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+	if rule.err != nil {
+		return nil, rule.err
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rule.status, http.StatusText(rule.status)),
+		StatusCode:    rule.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        rule.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(rule.reply)),
+		ContentLength: int64(len(rule.reply)),
+		Request:       req,
+	}, nil
+}
+
+// Calls returns the requests received so far, in order.
+func (m *MockTransport) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Call{}, m.calls...)
+}
+
+// CallCount counts the requests received for method and path. An empty method counts
+// any method.
+func (m *MockTransport) CallCount(method, path string) int {
+	path = "/" + strings.TrimPrefix(path, "/")
+	count := 0
+	for _, call := range m.Calls() {
+		if (method == "" || call.Method == method) && call.Path == path {
+			count++
+		}
+	}
+	return count
+}
+
+// AssertCalled fails the test unless method and path were requested exactly times times.
+func (m *MockTransport) AssertCalled(t testing.TB, method, path string, times int) {
+	t.Helper()
+	if count := m.CallCount(method, path); count != times {
+		t.Errorf("Expected %s %s to be called %d times, got %d", method, path, times, count)
+	}
+}
+
+// AssertNotCalled fails the test if method and path were requested.
+func (m *MockTransport) AssertNotCalled(t testing.TB, method, path string) {
+	t.Helper()
+	m.AssertCalled(t, method, path, 0)
+}
+
+// AssertExpectations fails the test if a rule never matched, or matched fewer times
+// than set with Times.
+func (m *MockTransport) AssertExpectations(t testing.TB) {
+	t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, rule := range m.rules {
+		expected := max(rule.times, 1)
+		if rule.matched < expected {
+			t.Errorf("Expected %s %s to be called %d times, got %d", rule.method, rule.path, expected, rule.matched)
+		}
+	}
+}
+
+func normalizeJSON(v any) (any, error) {
+	byts, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var normalized any
+	err = json.Unmarshal(byts, &normalized)
+	return normalized, err
+}
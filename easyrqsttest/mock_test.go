@@ -0,0 +1,84 @@
+package easyrqsttest
+
+import (
+	"context"
+	"errors"
+	"github.com/captain-bugs/easyrqst"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMockTransport(t *testing.T) {
+	mock := NewMockTransport()
+	mock.On(http.MethodGet, "/users").Query("page", "2").ReplyJSON(http.StatusOK, []string{"neo"})
+	mock.On(http.MethodPost, "/users").JSONBody(map[string]string{"name": "trinity"}).Reply(http.StatusCreated, "").Header("Location", "/users/2")
+	mock.On(http.MethodPost, "/users").Reply(http.StatusConflict, "")
+
+	call := easyrqst.NewHttpClient("http://api.test", mock.Option(), easyrqst.WithRetry(0))
+	resp, err := call.Get(easyrqst.WithPath("users"), easyrqst.WithQueries(map[string]string{"page": "2"}))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	users, err := easyrqst.Decode[[]string](resp)
+	if err != nil || len(users) != 1 || users[0] != "neo" {
+		t.Errorf("Expected [neo], got %v (%v)", users, err)
+	}
+
+	resp, err = call.Post(easyrqst.WithPath("users"), easyrqst.WithPayload(map[string]string{"name": "trinity"}))
+	if err != nil || resp.StatusCode != http.StatusCreated || resp.Header.Get("Location") != "/users/2" {
+		t.Errorf("Expected 201 with a Location, got %v (%v)", resp, err)
+	}
+	resp, err = call.Post(easyrqst.WithPath("users"), easyrqst.WithPayload(map[string]string{"name": "morpheus"}))
+	if err != nil || resp.StatusCode != http.StatusConflict {
+		t.Errorf("Expected 409 for another body, got %v (%v)", resp, err)
+	}
+
+	if _, err := call.Get(easyrqst.WithPath("missing")); err == nil || !strings.Contains(err.Error(), "no mock rule matches GET /missing") {
+		t.Errorf("Expected an unmatched request to fail, got %v", err)
+	}
+
+	mock.AssertCalled(t, http.MethodPost, "/users", 2)
+	mock.AssertNotCalled(t, http.MethodDelete, "/users")
+	mock.AssertExpectations(t)
+	if calls := mock.Calls(); len(calls) != 4 || string(calls[1].Body) != `{"name":"trinity"}` {
+		t.Errorf("Expected 4 recorded calls, got %+v", calls)
+	}
+}
+
+func TestMockTransportTimes(t *testing.T) {
+	mock := NewMockTransport()
+	mock.On("", "/flaky").Reply(http.StatusServiceUnavailable, "").Times(2)
+	mock.On("", "/flaky").Reply(http.StatusOK, "ok")
+
+	call := easyrqst.NewHttpClient("http://api.test", mock.Option(), easyrqst.WithRetry(2), easyrqst.WithRetryWaitMax(time.Millisecond))
+	resp, err := call.Get(easyrqst.WithPath("flaky"))
+	if err != nil || string(resp.Body) != "ok" {
+		t.Errorf("Expected retries to reach the second rule, got %v (%v)", resp, err)
+	}
+	mock.AssertCalled(t, "", "/flaky", 3)
+}
+
+func TestMockTransportFailAndDelay(t *testing.T) {
+	refused := errors.New("connection refused")
+	mock := NewMockTransport()
+	mock.On(http.MethodGet, "/down").Fail(refused)
+	mock.On(http.MethodGet, "/slow").Delay(time.Second)
+
+	call := easyrqst.NewHttpClient("http://api.test", mock.Option(), easyrqst.WithRetry(0))
+	if _, err := call.Get(easyrqst.WithPath("down")); err == nil || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("Expected the canned error, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := call.Get(easyrqst.WithPath("slow"), easyrqst.WithContext(ctx)); err == nil {
+		t.Errorf("Expected the delayed request to time out")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected the delay to stop on cancellation, took %v", elapsed)
+	}
+}
@@ -0,0 +1,90 @@
+package easyrqst
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+var encryptedMagic = []byte("eqe1")
+
+// EncryptedCodec encrypts the entries of another codec with AES-GCM. Every entry names
+// the key it was encrypted with, so keys can be rotated: make the new key current and
+// keep the old one until the entries it encrypted expired.
+type EncryptedCodec struct {
+	codec   ICacheCodec
+	current string
+	aeads   map[string]cipher.AEAD
+}
+
+// NewEncryptedCodec encrypts entries of codec, GobCodec when nil, with the key named
+// current. keys maps names to 16, 24 or 32 byte AES keys.
+func NewEncryptedCodec(codec ICacheCodec, current string, keys map[string][]byte) (*EncryptedCodec, error) {
+	if codec == nil {
+		codec = GobCodec{}
+	}
+	if _, ok := keys[current]; !ok {
+		return nil, fmt.Errorf("unknown current key %q", current)
+	}
+	if len(current) > 255 {
+		return nil, errors.New("key names must be shorter than 256 bytes")
+	}
+
+	aeads := make(map[string]cipher.AEAD, len(keys))
+	for name, key := range keys {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %q: %w", name, err)
+		}
+		if aeads[name], err = cipher.NewGCM(block); err != nil {
+			return nil, err
+		}
+	}
+	return &EncryptedCodec{codec: codec, current: current, aeads: aeads}, nil
+}
+
+func (c *EncryptedCodec) Encode(response *HttpResponse) ([]byte, error) {
+	plain, err := c.codec.Encode(response)
+	if err != nil {
+		return nil, err
+	}
+	aead := c.aeads[c.current]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	header := append(append([]byte{}, encryptedMagic...), byte(len(c.current)))
+	header = append(header, c.current...)
+	// The header is authenticated, so an entry can't be moved to another key name
+	sealed := aead.Seal(nil, nonce, plain, header)
+	return append(append(header, nonce...), sealed...), nil
+}
+
+func (c *EncryptedCodec) Decode(data []byte) (*HttpResponse, error) {
+	if !bytes.HasPrefix(data, encryptedMagic) || len(data) < len(encryptedMagic)+1 {
+		return nil, errors.New("cache entry is not encrypted")
+	}
+	nameEnd := len(encryptedMagic) + 1 + int(data[len(encryptedMagic)])
+	if len(data) < nameEnd {
+		return nil, errors.New("truncated encrypted cache entry")
+	}
+	name := string(data[len(encryptedMagic)+1 : nameEnd])
+	aead, ok := c.aeads[name]
+	if !ok {
+		return nil, fmt.Errorf("cache entry encrypted with unknown key %q", name)
+	}
+	if len(data) < nameEnd+aead.NonceSize() {
+		return nil, errors.New("truncated encrypted cache entry")
+	}
+
+	nonce := data[nameEnd : nameEnd+aead.NonceSize()]
+	plain, err := aead.Open(nil, nonce, data[nameEnd+aead.NonceSize():], data[:nameEnd])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt cache entry: %w", err)
+	}
+	return c.codec.Decode(plain)
+}
@@ -0,0 +1,74 @@
+package easyrqst
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestEncryptedCodec(t *testing.T) {
+	oldKey, newKey := bytes.Repeat([]byte{1}, 32), bytes.Repeat([]byte{2}, 16)
+	old, err := NewEncryptedCodec(nil, "2023", map[string][]byte{"2023": oldKey})
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	rotated, err := NewEncryptedCodec(nil, "2024", map[string][]byte{"2023": oldKey, "2024": newKey})
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+
+	response := &HttpResponse{StatusCode: http.StatusOK, Header: http.Header{}, Body: []byte(`{"ssn":"078-05-1120"}`)}
+	data, err := old.Encode(response)
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if bytes.Contains(data, []byte("078-05-1120")) {
+		t.Errorf("Expected the body to be encrypted")
+	}
+	if decoded, err := rotated.Decode(data); err != nil || string(decoded.Body) != string(response.Body) {
+		t.Errorf("Expected entries of the previous key to decrypt, got %v", err)
+	}
+
+	data, _ = rotated.Encode(response)
+	if _, err := old.Decode(data); err == nil {
+		t.Errorf("Expected an entry of an unknown key to fail")
+	}
+	data[len(data)-1] ^= 1
+	if _, err := rotated.Decode(data); err == nil {
+		t.Errorf("Expected a tampered entry to fail")
+	}
+
+	if _, err := NewEncryptedCodec(nil, "missing", map[string][]byte{"2024": newKey}); err == nil {
+		t.Errorf("Expected an unknown current key to fail")
+	}
+	if _, err := NewEncryptedCodec(nil, "short", map[string][]byte{"short": []byte("123")}); err == nil {
+		t.Errorf("Expected an invalid key size to fail")
+	}
+}
+
+func TestEncryptedCacheEntries(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte("token=s3cr3t"))
+	}))
+	defer server.Close()
+
+	codec, _ := NewEncryptedCodec(MsgpackCodec{}, "k1", map[string][]byte{"k1": bytes.Repeat([]byte{7}, 32)})
+	cache := newMapCache()
+	call := NewHttpClient(server.URL, WithCacheCodec(codec), WithDefaults(WithCache(cache, time.Minute, "")))
+	call.Get()
+	for _, value := range cache.items {
+		if bytes.Contains(value.([]byte), []byte("s3cr3t")) {
+			t.Errorf("Expected the cached entry to be encrypted")
+		}
+	}
+	if resp, _ := call.Get(); !resp.FromCache || string(resp.Body) != "token=s3cr3t" || calls != 1 {
+		t.Errorf("Expected the encrypted entry to be served")
+	}
+}
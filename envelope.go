@@ -0,0 +1,85 @@
+package easyrqst
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type envelopeObj struct {
+	dataPath  string
+	errorPath string
+}
+
+// EnvelopeError is returned when the error node of an enveloped response is set.
+type EnvelopeError struct {
+	StatusCode int
+	Raw        json.RawMessage
+}
+
+func (e *EnvelopeError) Error() string {
+	var message string
+	if json.Unmarshal(e.Raw, &message) == nil {
+		return message
+	}
+	var obj map[string]any
+	if json.Unmarshal(e.Raw, &obj) == nil {
+		for _, key := range []string{"message", "detail", "error"} {
+			if v, ok := obj[key].(string); ok {
+				return v
+			}
+		}
+	}
+	return string(e.Raw)
+}
+
+// WithEnvelope unwraps JSON envelopes such as {"data": ..., "error": ...}. Paths are dot
+// separated; Body receives the data node and a non-null error node is returned as an
+// *EnvelopeError. An empty errorPath disables error detection.
+func WithEnvelope(dataPath, errorPath string) TReqOption {
+	return func(o *ReqOptions) { o.envelope = &envelopeObj{dataPath: dataPath, errorPath: errorPath} }
+}
+
+func (h *HttpResponse) unwrap(envelope *envelopeObj) error {
+	var root any
+	decoder := json.NewDecoder(bytes.NewReader(h.Body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&root); err != nil {
+		return fmt.Errorf("failed to decode envelope: %w", err)
+	}
+
+	if envelope.errorPath != "" {
+		if node, ok := jsonPath(root, envelope.errorPath); ok && node != nil {
+			raw, _ := json.Marshal(node)
+			return &EnvelopeError{StatusCode: h.StatusCode, Raw: raw}
+		}
+	}
+
+	node, ok := jsonPath(root, envelope.dataPath)
+	if !ok {
+		return fmt.Errorf("envelope has no %q node", envelope.dataPath)
+	}
+	body, err := json.Marshal(node)
+	if err != nil {
+		return err
+	}
+	h.Body = body
+	return nil
+}
+
+func jsonPath(node any, path string) (any, bool) {
+	if path == "" {
+		return node, true
+	}
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := node.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if node, ok = obj[key]; !ok {
+			return nil, false
+		}
+	}
+	return node, true
+}
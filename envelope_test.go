@@ -0,0 +1,36 @@
+package easyrqst
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEnvelope(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("fail") == "1" {
+			w.Write([]byte(`{"result":{"data":null},"error":{"code":"E42","message":"not allowed"}}`))
+			return
+		}
+		w.Write([]byte(`{"result":{"data":{"id":12345678901234567}},"error":null}`))
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithDefaults(WithEnvelope("result.data", "error")))
+
+	outcome, err := call.Get()
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if string(outcome.Body) != `{"id":12345678901234567}` {
+		t.Errorf("Expected unwrapped data, got %s", outcome.Body)
+	}
+
+	_, err = call.Get(WithQueries(map[string]string{"fail": "1"}))
+	var envelopeErr *EnvelopeError
+	if !errors.As(err, &envelopeErr) || envelopeErr.Error() != "not allowed" {
+		t.Errorf("Expected envelope error, got %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package easyrqst
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// WithErrorDecoder decodes the body of responses with status into the value returned by
+// target, which must be a pointer to a type implementing error. Get, Post and Custom then
+// return an *HttpError wrapping it, so errors.As finds the API error. A status of 0 is
+// used for non-2xx responses without a decoder of their own.
+func WithErrorDecoder(status int, target func() any) THttpOption {
+	return func(o *easyRequest) {
+		if o.errorDecoders == nil {
+			o.errorDecoders = make(map[int]func() any)
+		}
+		o.errorDecoders[status] = target
+	}
+}
+
+func (h *easyRequest) errorDecoder(status int) (func() any, bool) {
+	if decode, ok := h.errorDecoders[status]; ok {
+		return decode, true
+	}
+	decode, ok := h.errorDecoders[0]
+	return decode, ok
+}
+
+func decodeError(response *HttpResponse, target func() any) error {
+	v := target()
+	typ := reflect.TypeOf(v)
+	if typ == nil || typ.Kind() != reflect.Pointer {
+		return fmt.Errorf("error decoder for status %d returned %T, not a pointer", response.StatusCode, v)
+	}
+	decoded, ok := v.(error)
+	if !ok {
+		return fmt.Errorf("error decoder for status %d returned %T, which is not an error", response.StatusCode, v)
+	}
+	if err := response.unmarshal(v, typ.Elem()); err != nil {
+		return fmt.Errorf("failed to decode error body: %w", err)
+	}
+	return decoded
+}
@@ -0,0 +1,72 @@
+package easyrqst
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type apiError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *apiError) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+type notAnError struct{}
+
+func TestWithErrorDecoder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/invalid":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"code": "invalid_name", "message": "name is required"}`))
+		case "/broken":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`<html>`))
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL,
+		WithErrorDecoder(http.StatusUnprocessableEntity, func() any { return &apiError{} }),
+		WithErrorDecoder(http.StatusBadRequest, func() any { return &apiError{} }),
+		WithErrorDecoder(http.StatusNotFound, func() any { return &notAnError{} }),
+	)
+
+	_, err := call.Post(WithPath("/invalid"))
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) || apiErr.Code != "invalid_name" || apiErr.Message != "name is required" {
+		t.Fatalf("Expected the decoded API error, got %v", err)
+	}
+	var httpErr *HttpError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected an *HttpError with the status, got %v", err)
+	}
+
+	_, err = call.Get(WithPath("/broken"))
+	if !errors.As(err, &httpErr) || !strings.Contains(err.Error(), "failed to decode error body") {
+		t.Errorf("Expected the decoding failure in an *HttpError, got %v", err)
+	}
+	_, err = call.Get(WithPath("/missing"))
+	if err == nil || !strings.Contains(err.Error(), "not an error") {
+		t.Errorf("Expected an invalid decoder to be reported, got %v", err)
+	}
+	if _, err := call.Get(); err != nil {
+		t.Errorf("Expected no error for a 200, got %v", err)
+	}
+	if _, err := call.Get(WithPath("/unknown")); err != nil {
+		t.Errorf("Expected no error for statuses without a decoder, got %v", err)
+	}
+
+	fallback := NewHttpClient(server.URL, WithErrorDecoder(0, func() any { return &apiError{} }))
+	if _, err := fallback.Get(WithPath("/invalid")); !errors.As(err, &apiErr) {
+		t.Errorf("Expected the fallback decoder to apply, got %v", err)
+	}
+}
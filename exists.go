@@ -0,0 +1,31 @@
+package easyrqst
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Exists checks for a resource with a HEAD request: a 2xx means it exists and a 404 or
+// 410 that it doesn't. Servers that don't support HEAD are asked with a GET whose body
+// is discarded. Any other status is returned as an error.
+func (h *easyRequest) Exists(opts ...TReqOption) (bool, error) {
+	outcome, err := h.do(http.MethodHead, opts...)
+	if err != nil {
+		return false, err
+	}
+	if outcome.StatusCode == http.StatusMethodNotAllowed || outcome.StatusCode == http.StatusNotImplemented {
+		discard := WithStream(func(io.Reader) error { return nil })
+		if outcome, err = h.do(http.MethodGet, append(opts[:len(opts):len(opts)], discard)...); err != nil {
+			return false, err
+		}
+	}
+
+	switch {
+	case outcome.StatusCode >= 200 && outcome.StatusCode < 300:
+		return true, nil
+	case outcome.StatusCode == http.StatusNotFound || outcome.StatusCode == http.StatusGone:
+		return false, nil
+	}
+	return false, fmt.Errorf("unexpected status code %d", outcome.StatusCode)
+}
@@ -0,0 +1,46 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExists(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/forbidden":
+			w.WriteHeader(http.StatusForbidden)
+		case "/nohead":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.Write([]byte("a large object"))
+		}
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL)
+	for path, expected := range map[string]bool{"present": true, "missing": false, "nohead": true} {
+		exists, err := call.Exists(WithPath(path))
+		if err != nil {
+			t.Errorf("Error: %v", err)
+			continue
+		}
+		if exists != expected {
+			t.Errorf("Expected %s to exist: %v, got %v", path, expected, exists)
+		}
+	}
+	if len(methods) != 4 {
+		t.Errorf("Expected a GET fallback only for nohead, got %v", methods)
+	}
+
+	if _, err := call.Exists(WithPath("forbidden")); err == nil {
+		t.Errorf("Expected error for unexpected status")
+	}
+}
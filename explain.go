@@ -0,0 +1,101 @@
+package easyrqst
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Resolution is the effective configuration of a request once client defaults and
+// request options have been merged.
+type Resolution struct {
+	Method       string
+	URL          string
+	Header       http.Header
+	Encoder      string
+	CacheKey     string
+	CacheExpiry  time.Duration
+	MaxRetry     int
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+	Expectations int
+	Signed       bool
+}
+
+func (r *Resolution) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n", r.Method, r.URL)
+	fmt.Fprintf(&b, "encoder: %s\n", r.Encoder)
+
+	keys := make([]string, 0, len(r.Header))
+	for k := range r.Header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&b, "header: %s: %s\n", k, strings.Join(r.Header[k], ", "))
+	}
+
+	if r.CacheKey != "" {
+		fmt.Fprintf(&b, "cache: key=%s expiry=%s\n", r.CacheKey, r.CacheExpiry)
+	} else {
+		b.WriteString("cache: disabled\n")
+	}
+	fmt.Fprintf(&b, "retry: max=%d wait_min=%s wait_max=%s expectations=%d\n", r.MaxRetry, r.RetryWaitMin, r.RetryWaitMax, r.Expectations)
+	fmt.Fprintf(&b, "signed: %v\n", r.Signed)
+	return b.String()
+}
+
+type explainKey struct{}
+
+// Explain resolves a request without sending it. Editors such as signers run as they
+// would for a real request, token providers aren't asked for a token. Credentials in
+// the headers and the query are redacted.
+func (h *easyRequest) Explain(method string, opts ...TReqOption) (*Resolution, error) {
+	explaining := func(o *ReqOptions) { o.ctx = context.WithValue(o.ctx, explainKey{}, true) }
+	req, options, err := h.prepareRequest(method, h.endpoint, append(opts[:len(opts):len(opts)], explaining)...)
+	if err != nil {
+		return nil, err
+	}
+
+	resolution := &Resolution{
+		Method:       req.Method,
+		URL:          redactURL(req.URL, h.secretParams(options)...),
+		Header:       redactHeader(req.Header, h.secretHeaders(options)...),
+		Encoder:      encoderOf(req),
+		MaxRetry:     h.maxRetry,
+		RetryWaitMin: h.retryWaitMin,
+		RetryWaitMax: h.retryWaitMax,
+		Expectations: len(options.expect),
+		Signed:       h.signer != nil,
+	}
+	if options.cacheObj != nil && options.cacheObj.fncs != nil {
+		resolution.CacheKey = h.cacheKey(req, options.cacheObj)
+		resolution.CacheExpiry = options.cacheObj.expiry
+	}
+	return resolution, nil
+}
+
+// explaining reports whether the request is only being resolved by Explain.
+func explaining(ctx context.Context) bool {
+	return ctx.Value(explainKey{}) != nil
+}
+
+func encoderOf(req *http.Request) string {
+	if req.Body == nil {
+		return "none"
+	}
+	contentType := req.Header.Get("Content-Type")
+	switch {
+	case contentType == "application/x-www-form-urlencoded":
+		return "form"
+	case strings.HasPrefix(contentType, "multipart/form-data"):
+		return "multipart"
+	case contentType == "application/xml":
+		return "xml"
+	}
+	return "json"
+}
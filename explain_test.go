@@ -0,0 +1,63 @@
+package easyrqst
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExplain(t *testing.T) {
+	call := NewHttpClient("http://localhost:9000/form", WithRetry(2), WithDefaults(WithHeaders(map[string]string{"X-Client": "easyrqst"})))
+
+	resolution, err := call.Explain("POST",
+		WithPayload(multipartPayload),
+		WithMergedHeaders(map[string]string{"Content-Type": "application/x-www-form-urlencoded"}),
+		WithQueries(map[string]string{"page": "2"}),
+		WithCache(newMapCache(), time.Minute, "explain"),
+	)
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if resolution.URL != "http://localhost:9000/form?page=2" {
+		t.Errorf("Expected query in URL, got %s", resolution.URL)
+	}
+	if resolution.Encoder != "form" {
+		t.Errorf("Expected form encoder, got %s", resolution.Encoder)
+	}
+	if resolution.Header.Get("X-Client") != "easyrqst" {
+		t.Errorf("Expected client default header, got %v", resolution.Header)
+	}
+	if resolution.CacheKey != "POST_explain_/form?page=2" || resolution.MaxRetry != 2 {
+		t.Errorf("Unexpected resolution %+v", resolution)
+	}
+	if !strings.Contains(resolution.String(), "encoder: form") {
+		t.Errorf("Expected encoder in report, got %s", resolution)
+	}
+}
+
+func TestExplainRedactsCredentials(t *testing.T) {
+	provided := 0
+	call := NewHttpClient("http://api.test", WithTokenProvider(func(ctx context.Context) (string, error) {
+		provided++
+		return "t0k3n", nil
+	}))
+
+	resolution, err := call.Explain("GET", WithAPIKey("X-Auth", "h3ad3r", InHeader), WithAPIKey("k", "qu3ry", InQuery))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	report := resolution.String()
+	for _, secret := range []string{"t0k3n", "h3ad3r", "qu3ry"} {
+		if strings.Contains(report, secret) {
+			t.Errorf("Expected %s to be redacted, got %s", secret, report)
+		}
+	}
+	if resolution.Header.Get("Authorization") == "" {
+		t.Errorf("Expected the Authorization header to be listed, got %v", resolution.Header)
+	}
+	if provided != 0 {
+		t.Errorf("Expected the token provider not to be called, got %d calls", provided)
+	}
+}
@@ -0,0 +1,74 @@
+package easyrqst
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// failover spreads requests over the primary endpoint and its fallbacks, in that order,
+// skipping endpoints that failed recently.
+type failover struct {
+	mu        sync.Mutex
+	endpoints []string
+	downUntil map[string]time.Time
+	recheck   time.Duration
+}
+
+// WithFallbackEndpoints tries the fallbacks in order when the endpoint fails with a
+// connection error or a 5xx once retries are exhausted. A failed endpoint is skipped
+// until the recheck interval passes, after which the primary is promoted again as soon
+// as it answers.
+func WithFallbackEndpoints(endpoints ...string) THttpOption {
+	return func(o *easyRequest) { o.fallbacks = append(o.fallbacks, endpoints...) }
+}
+
+// WithFailoverRecheck sets how long a failed endpoint is skipped, 30 seconds by default.
+func WithFailoverRecheck(interval time.Duration) THttpOption {
+	return func(o *easyRequest) { o.failoverRecheck = interval }
+}
+
+func newFailover(endpoints []string, recheck time.Duration) *failover {
+	return &failover{endpoints: endpoints, downUntil: make(map[string]time.Time), recheck: recheck}
+}
+
+// candidates returns the healthy endpoints in order, or all of them if none is. up
+// reports the verdict of health checks.
+func (f *failover) candidates(up func(string) bool) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+	var healthy []string
+	for _, endpoint := range f.endpoints {
+		if now.After(f.downUntil[endpoint]) && up(endpoint) {
+			healthy = append(healthy, endpoint)
+		}
+	}
+	if len(healthy) == 0 {
+		return f.endpoints
+	}
+	return healthy
+}
+
+// report records the outcome of a request to endpoint and tells whether it failed in a
+// way another endpoint could do better.
+func (f *failover) report(endpoint string, response *HttpResponse, err error) bool {
+	var urlErr *url.Error
+	failed := (err == nil && response.StatusCode >= 500) ||
+		(errors.As(err, &urlErr) && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded))
+	if err != nil && !failed {
+		return false
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if failed {
+		f.downUntil[endpoint] = time.Now().Add(f.recheck)
+	} else {
+		delete(f.downUntil, endpoint)
+	}
+	return failed
+}
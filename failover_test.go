@@ -0,0 +1,63 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFailoverEndpoints(t *testing.T) {
+	primaryUp := false
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !primaryUp {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("primary"))
+	}))
+	defer primary.Close()
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("fallback"))
+	}))
+	defer fallback.Close()
+
+	call := NewHttpClient(primary.URL, WithRetry(0), WithFallbackEndpoints("http://127.0.0.1:1", fallback.URL), WithFailoverRecheck(50*time.Millisecond))
+	expect := func(expected string) {
+		t.Helper()
+		outcome, err := call.Get()
+		if err != nil {
+			t.Errorf("Error: %v", err)
+			return
+		}
+		if string(outcome.Body) != expected {
+			t.Errorf("Expected %s, got %s", expected, outcome.Body)
+		}
+	}
+
+	expect("fallback")
+	primaryUp = true
+	expect("fallback")
+	time.Sleep(60 * time.Millisecond)
+	expect("primary")
+}
+
+func TestFailoverSkipsNonIdempotent(t *testing.T) {
+	calls := 0
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer primary.Close()
+	fallback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { calls++ }))
+	defer fallback.Close()
+
+	call := NewHttpClient(primary.URL, WithRetry(0), WithFallbackEndpoints(fallback.URL))
+	outcome, err := call.Post()
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if outcome.StatusCode != http.StatusInternalServerError || calls != 0 {
+		t.Errorf("Expected POST not to be failed over, got %v with %v fallback calls", outcome.StatusCode, calls)
+	}
+}
@@ -0,0 +1,134 @@
+package easyrqst
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// FaultConfig sets the probability, from 0 to 1, of each fault injected by
+// WithFaultInjection. At most one of reset, timeout and status is injected per attempt;
+// latency comes on top of it.
+type FaultConfig struct {
+	LatencyRate float64
+	Latency     time.Duration
+	ResetRate   float64
+	TimeoutRate float64
+	// Timeout is how long a timed out attempt hangs unless canceled first, 1s by default
+	Timeout    time.Duration
+	StatusRate float64
+	// Statuses are picked at random for injected error responses, 503 by default
+	Statuses []int
+	// Seed makes the sequence of faults reproducible, 0 picks a random one
+	Seed int64
+}
+
+// FaultError is returned by attempts failed by fault injection.
+type FaultError struct {
+	Kind string
+	err  error
+}
+
+func (e *FaultError) Error() string {
+	return fmt.Sprintf("injected %s: %v", e.Kind, e.err)
+}
+
+func (e *FaultError) Unwrap() error {
+	return e.err
+}
+
+// Timeout lets the retry layer and callers treat injected timeouts like real ones.
+func (e *FaultError) Timeout() bool {
+	return e.Kind == "timeout"
+}
+
+func (e *FaultError) Temporary() bool {
+	return true
+}
+
+// WithFaultInjection randomly delays and fails attempts before they hit the network, to
+// exercise retries, circuit breakers and timeouts. It is a no-op unless the binary is
+// built with the easyrqst_faults tag, so faults can't be injected in production.
+func WithFaultInjection(config FaultConfig) THttpOption {
+	return func(o *easyRequest) { o.faults = &config }
+}
+
+type faultTransport struct {
+	base   http.RoundTripper
+	config FaultConfig
+	mu     sync.Mutex
+	rand   *rand.Rand
+}
+
+func newFaultTransport(base http.RoundTripper, config FaultConfig) *faultTransport {
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = time.Second
+	}
+	if len(config.Statuses) == 0 {
+		config.Statuses = []int{http.StatusServiceUnavailable}
+	}
+	return &faultTransport{base: base, config: config, rand: rand.New(rand.NewSource(seed))}
+}
+
+// draw decides the faults of one attempt.
+func (t *faultTransport) draw() (delay bool, fault float64, status int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delay = t.rand.Float64() < t.config.LatencyRate
+	fault = t.rand.Float64()
+	status = t.config.Statuses[t.rand.Intn(len(t.config.Statuses))]
+	return delay, fault, status
+}
+
+func (t *faultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	delay, fault, status := t.draw()
+	if delay {
+		if err := t.wait(req, t.config.Latency); err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case fault < t.config.ResetRate:
+		return nil, &FaultError{Kind: "reset", err: syscall.ECONNRESET}
+	case fault < t.config.ResetRate+t.config.TimeoutRate:
+		if err := t.wait(req, t.config.Timeout); err != nil {
+			return nil, err
+		}
+		return nil, &FaultError{Kind: "timeout", err: fmt.Errorf("no response after %v", t.config.Timeout)}
+	case fault < t.config.ResetRate+t.config.TimeoutRate+t.config.StatusRate:
+		body := []byte(http.StatusText(status))
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+			StatusCode:    status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": {"text/plain"}, "X-Injected-Fault": {"status"}},
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+	return t.base.RoundTrip(req)
+}
+
+func (t *faultTransport) wait(req *http.Request, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
@@ -0,0 +1,6 @@
+//go:build !easyrqst_faults
+
+package easyrqst
+
+// faultsEnabled is off by default, making WithFaultInjection a no-op.
+const faultsEnabled = false
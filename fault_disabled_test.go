@@ -0,0 +1,20 @@
+//go:build !easyrqst_faults
+
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFaultInjectionDisabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithRetry(0), WithFaultInjection(FaultConfig{StatusRate: 1, ResetRate: 1}))
+	outcome, err := call.Get()
+	if err != nil || outcome.StatusCode != http.StatusOK {
+		t.Errorf("Expected faults not to be injected without the easyrqst_faults tag, got %v, %v", outcome, err)
+	}
+}
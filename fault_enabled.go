@@ -0,0 +1,7 @@
+//go:build easyrqst_faults
+
+package easyrqst
+
+// faultsEnabled is only on in builds with the easyrqst_faults tag, e.g. test and
+// staging binaries.
+const faultsEnabled = true
@@ -0,0 +1,107 @@
+//go:build easyrqst_faults
+
+package easyrqst
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestFaultInjectionStatus(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { hits.Add(1) }))
+	defer server.Close()
+
+	var injected *http.Response
+	call := NewHttpClient(server.URL, WithRetry(0),
+		WithHooks(Hooks{OnResponse: func(event HookEvent) { injected = event.Response }}),
+		WithFaultInjection(FaultConfig{StatusRate: 1, Statuses: []int{http.StatusBadGateway}}))
+	if _, err := call.Get(); err == nil {
+		t.Errorf("Expected the injected 502 to fail the request")
+	}
+	if injected == nil || injected.StatusCode != http.StatusBadGateway || injected.Header.Get("X-Injected-Fault") != "status" || hits.Load() != 0 {
+		t.Errorf("Expected an injected 502 without reaching the server, got %v after %d hits", injected, hits.Load())
+	}
+}
+
+func TestFaultInjectionReset(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithRetry(0), WithFaultInjection(FaultConfig{ResetRate: 1}))
+	_, err := call.Get()
+	var fault *FaultError
+	if !errors.As(err, &fault) || fault.Kind != "reset" || !errors.Is(err, syscall.ECONNRESET) {
+		t.Errorf("Expected an injected connection reset, got %v", err)
+	}
+}
+
+func TestFaultInjectionTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithRetry(0), WithFaultInjection(FaultConfig{TimeoutRate: 1, Timeout: time.Minute}))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := call.Get(WithContext(ctx)); err == nil {
+		t.Errorf("Expected the injected timeout to fail the request")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the hang to stop on cancellation, took %v", elapsed)
+	}
+}
+
+func TestFaultInjectionSeed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	run := func() []int {
+		var statuses []int
+		call := NewHttpClient(server.URL, WithRetry(0),
+			WithHooks(Hooks{OnResponse: func(event HookEvent) { statuses = append(statuses, event.Response.StatusCode) }}),
+			WithFaultInjection(FaultConfig{StatusRate: 0.5, Seed: 42}))
+		for i := 0; i < 20; i++ {
+			call.Get()
+		}
+		return statuses
+	}
+
+	first, second := run(), run()
+	injected := 0
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("Expected the same faults for the same seed, got %v and %v", first, second)
+			return
+		}
+		if first[i] == http.StatusServiceUnavailable {
+			injected++
+		}
+	}
+	if injected == 0 || injected == len(first) {
+		t.Errorf("Expected some faults injected, got %v", first)
+	}
+}
+
+func TestFaultInjectionRetried(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	var retries atomic.Int32
+	call := NewHttpClient(server.URL, WithRetry(5), WithRetryWaitMax(time.Millisecond),
+		WithHooks(Hooks{OnRetry: func(HookEvent) { retries.Add(1) }}),
+		WithFaultInjection(FaultConfig{ResetRate: 0.5, Seed: 7}))
+	resp, err := call.Get()
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected retries to get past injected resets, got %v", err)
+	}
+	if retries.Load() == 0 {
+		t.Errorf("Expected injected resets to be retried")
+	}
+}
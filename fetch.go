@@ -0,0 +1,194 @@
+package easyrqst
+
+import (
+	"container/list"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+type FetchState int
+
+const (
+	FetchError FetchState = iota
+	FetchUnchanged
+	FetchUpdated
+)
+
+func (s FetchState) String() string {
+	switch s {
+	case FetchUnchanged:
+		return "unchanged"
+	case FetchUpdated:
+		return "updated"
+	}
+	return "error"
+}
+
+type validators struct {
+	etag         string
+	lastModified string
+}
+
+func (v validators) empty() bool {
+	return v.etag == "" && v.lastModified == ""
+}
+
+func (v validators) headers() map[string]string {
+	headers := make(map[string]string)
+	if v.etag != "" {
+		headers["If-None-Match"] = v.etag
+	}
+	if v.lastModified != "" {
+		headers["If-Modified-Since"] = v.lastModified
+	}
+	return headers
+}
+
+func validatorsOf(header http.Header) validators {
+	return validators{etag: header.Get("ETag"), lastModified: header.Get("Last-Modified")}
+}
+
+// maxValidators is the number of URLs whose validators are remembered; the least
+// recently used ones are forgotten first.
+const maxValidators = 10000
+
+type validatorEntry struct {
+	key string
+	validators
+}
+
+type validatorStore struct {
+	mu    sync.Mutex
+	order *list.List
+	items map[string]*list.Element
+}
+
+func (s *validatorStore) get(key string) validators {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	el, ok := s.items[key]
+	if !ok {
+		return validators{}
+	}
+	s.order.MoveToFront(el)
+	return el.Value.(*validatorEntry).validators
+}
+
+func (s *validatorStore) set(key string, v validators) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.items == nil {
+		s.order = list.New()
+		s.items = make(map[string]*list.Element)
+	}
+	if el, ok := s.items[key]; ok {
+		el.Value.(*validatorEntry).validators = v
+		s.order.MoveToFront(el)
+		return
+	}
+	s.items[key] = s.order.PushFront(&validatorEntry{key: key, validators: v})
+	if s.order.Len() > maxValidators {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.items, oldest.Value.(*validatorEntry).key)
+	}
+}
+
+// resourceKey is the key the validators of the GET request opts make are remembered
+// under, shared with WithConditionalRequests. It is worked out without authenticating
+// or signing the request.
+func (h *easyRequest) resourceKey(opts ...TReqOption) (string, error) {
+	options := h.applyOptions(opts...)
+	target, err := h.requestURL(h.endpoint, &options)
+	if err != nil {
+		return "", err
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return "", err
+	}
+	return validatorKey(u, &options), nil
+}
+
+// validatorKey is u without the query credentials of options, which editors add to the
+// URL of requests but aren't part of resourceKey.
+func validatorKey(u *url.URL, options *ReqOptions) string {
+	if len(options.secretParams) == 0 {
+		return u.String()
+	}
+	stripped := *u
+	query := u.Query()
+	for _, name := range options.secretParams {
+		query.Del(name)
+	}
+	stripped.RawQuery = query.Encode()
+	return stripped.String()
+}
+
+// FetchIfChanged downloads the resource only when it changed since the previous call.
+// A cheap HEAD is compared against the remembered ETag/Last-Modified first, then a
+// conditional GET fetches the body; a 304 is reported as FetchUnchanged.
+func (h *easyRequest) FetchIfChanged(opts ...TReqOption) (FetchState, *HttpResponse, error) {
+	key, err := h.resourceKey(opts...)
+	if err != nil {
+		return FetchError, nil, err
+	}
+	known := h.validators.get(key)
+
+	if !known.empty() {
+		head, err := h.do(http.MethodHead, opts...)
+		if err != nil {
+			return FetchError, nil, err
+		}
+		if head.StatusCode >= 200 && head.StatusCode < 300 {
+			if current := validatorsOf(head.Header); !current.empty() && current == known {
+				return FetchUnchanged, head, nil
+			}
+		}
+		opts = append(opts, WithMergedHeaders(known.headers()))
+	}
+
+	outcome, err := h.do(http.MethodGet, opts...)
+	if err != nil {
+		return FetchError, nil, err
+	}
+	switch {
+	case outcome.StatusCode == http.StatusNotModified:
+		return FetchUnchanged, outcome, nil
+	case outcome.StatusCode >= 200 && outcome.StatusCode < 300:
+		h.validators.set(key, validatorsOf(outcome.Header))
+		return FetchUpdated, outcome, nil
+	}
+	return FetchError, outcome, fmt.Errorf("unexpected status code %d", outcome.StatusCode)
+}
+
+// WithConditionalRequests remembers the ETag/Last-Modified of every GET response and
+// sends them as If-None-Match/If-Modified-Since on later requests for the same URL.
+// Unchanged resources then come back as 304 Not Modified without a body. Validators of
+// the 10000 most recently used URLs are kept.
+func WithConditionalRequests() THttpOption {
+	return func(o *easyRequest) { o.conditional = true }
+}
+
+func (h *easyRequest) injectValidators(req *http.Request, options *ReqOptions) {
+	if !h.conditional || (req.Method != http.MethodGet && req.Method != http.MethodHead) {
+		return
+	}
+	if req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" {
+		return
+	}
+	for k, v := range h.validators.get(validatorKey(req.URL, options)).headers() {
+		req.Header.Set(k, v)
+	}
+}
+
+func (h *easyRequest) rememberValidators(req *http.Request, options *ReqOptions, response *HttpResponse) {
+	if !h.conditional || req.Method != http.MethodGet || response.StatusCode < 200 || response.StatusCode >= 300 {
+		return
+	}
+	if current := validatorsOf(response.Header); !current.empty() {
+		h.validators.set(validatorKey(req.URL, options), current)
+	}
+}
@@ -0,0 +1,152 @@
+package easyrqst
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFetchIfChanged(t *testing.T) {
+	etag := `"v1"`
+	downloads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if r.Method == http.MethodGet {
+			downloads++
+		}
+		w.Write([]byte("artifact " + etag))
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL)
+	expect := func(expected FetchState) {
+		t.Helper()
+		state, _, err := call.FetchIfChanged()
+		if err != nil {
+			t.Errorf("Error: %v", err)
+		}
+		if state != expected {
+			t.Errorf("Expected %v, got %v", expected, state)
+		}
+	}
+
+	expect(FetchUpdated)
+	expect(FetchUnchanged)
+	etag = `"v2"`
+	expect(FetchUpdated)
+	expect(FetchUnchanged)
+
+	if downloads != 2 {
+		t.Errorf("Expected 2 downloads, got %v", downloads)
+	}
+}
+
+func TestConditionalRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte("report"))
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithConditionalRequests())
+	for _, expected := range []int{http.StatusOK, http.StatusNotModified} {
+		outcome, err := call.Get()
+		if err != nil {
+			t.Errorf("Error: %v", err)
+			return
+		}
+		if outcome.StatusCode != expected {
+			t.Errorf("Expected %v, got %v", expected, outcome.StatusCode)
+		}
+	}
+
+	outcome, err := call.Get(WithQueries(map[string]string{"page": "2"}))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if outcome.StatusCode != http.StatusOK {
+		t.Errorf("Expected other URLs to be fetched unconditionally, got %v", outcome.StatusCode)
+	}
+}
+
+func TestFetchIfChangedPerPath(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag := `"` + r.URL.Path + `"`
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithConditionalRequests()).(*easyRequest)
+	for _, path := range []string{"/a", "/b"} {
+		if state, _, err := call.FetchIfChanged(WithPath(path)); err != nil || state != FetchUpdated {
+			t.Errorf("Expected %s to be downloaded, got %v, %v", path, state, err)
+		}
+	}
+	if state, _, err := call.FetchIfChanged(WithPath("/a")); err != nil || state != FetchUnchanged {
+		t.Errorf("Expected /a to be unchanged, got %v, %v", state, err)
+	}
+	if known := call.validators.get(server.URL + "/b"); known.etag != `"/b"` {
+		t.Errorf("Expected validators shared with conditional requests, got %+v", known)
+	}
+}
+
+func TestValidatorStoreBounds(t *testing.T) {
+	var store validatorStore
+	for i := 0; i <= maxValidators; i++ {
+		if i == maxValidators {
+			store.get("0")
+		}
+		store.set(strconv.Itoa(i), validators{etag: strconv.Itoa(i)})
+	}
+	if len(store.items) != maxValidators || store.get("1").etag != "" || store.get("0").etag == "" {
+		t.Errorf("Expected the least recently used validators to be forgotten, kept %d", len(store.items))
+	}
+	if store.get(strconv.Itoa(maxValidators)).etag == "" {
+		t.Errorf("Expected the latest validators to be kept")
+	}
+}
+
+func TestFetchIfChangedKeyWithoutAuth(t *testing.T) {
+	var conditional atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			conditional.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+		}
+	}))
+	defer server.Close()
+
+	var tokens atomic.Int32
+	call := NewHttpClient(server.URL, WithConditionalRequests(), WithTokenProvider(func(ctx context.Context) (string, error) {
+		tokens.Add(1)
+		return "t0k3n", nil
+	}))
+	key := WithAPIKey("api_key", "s3cr3t", InQuery)
+	if state, _, err := call.FetchIfChanged(key); err != nil || state != FetchUpdated {
+		t.Fatalf("Expected a download, got %v, %v", state, err)
+	}
+	if tokens.Load() != 1 {
+		t.Errorf("Expected a token for the download only, got %d", tokens.Load())
+	}
+	if outcome, err := call.Get(key); err != nil || outcome.StatusCode != http.StatusNotModified || conditional.Load() != 1 {
+		t.Errorf("Expected validators to be shared with conditional requests, got %v, %v", outcome, err)
+	}
+}
@@ -0,0 +1,81 @@
+package easyrqst
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+)
+
+var ErrFIPSViolation = errors.New("fips: non-approved configuration")
+
+var fipsCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+}
+
+var fipsCurves = []tls.CurveID{tls.CurveP256, tls.CurveP384, tls.CurveP521}
+
+var fipsSigningAlgorithms = []string{"hmac-sha256"}
+
+// WithFIPSMode restricts TLS to FIPS-approved versions, cipher suites and curves and
+// makes every request fail with ErrFIPSViolation when the binary does not run a FIPS
+// validated crypto module or the client is configured with non-approved algorithms.
+func WithFIPSMode() THttpOption {
+	return func(o *easyRequest) {
+		o.fips = true
+		cfg := o.tlsConfig()
+		cfg.MinVersion = tls.VersionTLS12
+		cfg.CipherSuites = fipsCipherSuites
+		cfg.CurvePreferences = fipsCurves
+	}
+}
+
+func (h *easyRequest) checkFIPS() error {
+	if !h.fips {
+		return nil
+	}
+	if !FIPSEnabled() {
+		return fmt.Errorf("%w: crypto module is not running in FIPS mode", ErrFIPSViolation)
+	}
+	if h.signer != nil {
+		s, ok := h.signer.(interface{ Algorithm() string })
+		if !ok || !contains(fipsSigningAlgorithms, s.Algorithm()) {
+			return fmt.Errorf("%w: signer %T", ErrFIPSViolation, h.signer)
+		}
+	}
+	return fipsTLSError(h.transport.TLSClientConfig)
+}
+
+func fipsTLSError(cfg *tls.Config) error {
+	if cfg == nil {
+		return fmt.Errorf("%w: tls is not configured", ErrFIPSViolation)
+	}
+	if cfg.InsecureSkipVerify {
+		return fmt.Errorf("%w: certificate verification is disabled", ErrFIPSViolation)
+	}
+	if cfg.MinVersion < tls.VersionTLS12 {
+		return fmt.Errorf("%w: tls version %s is allowed", ErrFIPSViolation, tls.VersionName(cfg.MinVersion))
+	}
+	for _, suite := range cfg.CipherSuites {
+		if !contains(fipsCipherSuites, suite) {
+			return fmt.Errorf("%w: cipher suite %s", ErrFIPSViolation, tls.CipherSuiteName(suite))
+		}
+	}
+	for _, curve := range cfg.CurvePreferences {
+		if !contains(fipsCurves, curve) {
+			return fmt.Errorf("%w: curve %s", ErrFIPSViolation, curve)
+		}
+	}
+	return nil
+}
+
+func contains[T comparable](items []T, item T) bool {
+	for _, v := range items {
+		if v == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,10 @@
+//go:build !go1.24 && boringcrypto
+
+package easyrqst
+
+import "crypto/boring"
+
+// FIPSEnabled reports whether the binary is built with BoringCrypto.
+func FIPSEnabled() bool {
+	return boring.Enabled()
+}
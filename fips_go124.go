@@ -0,0 +1,10 @@
+//go:build go1.24
+
+package easyrqst
+
+import "crypto/fips140"
+
+// FIPSEnabled reports whether the Go cryptographic module runs in FIPS 140-3 mode.
+func FIPSEnabled() bool {
+	return fips140.Enabled()
+}
@@ -0,0 +1,8 @@
+//go:build !go1.24 && !boringcrypto
+
+package easyrqst
+
+// FIPSEnabled reports false, this toolchain has no FIPS validated crypto module.
+func FIPSEnabled() bool {
+	return false
+}
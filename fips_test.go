@@ -0,0 +1,33 @@
+package easyrqst
+
+import (
+	"crypto/tls"
+	"errors"
+	"testing"
+)
+
+func TestFIPSTLSConfig(t *testing.T) {
+	call := NewHttpClient("https://localhost", WithFIPSMode()).(*easyRequest)
+	if err := fipsTLSError(call.transport.TLSClientConfig); err != nil {
+		t.Errorf("Expected approved configuration, got %v", err)
+	}
+
+	WithTLSPolicy(TLSLegacy)(call)
+	if err := fipsTLSError(call.transport.TLSClientConfig); !errors.Is(err, ErrFIPSViolation) {
+		t.Errorf("Expected ErrFIPSViolation for legacy policy, got %v", err)
+	}
+
+	if err := fipsTLSError(&tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: true}); !errors.Is(err, ErrFIPSViolation) {
+		t.Errorf("Expected ErrFIPSViolation when skipping verification, got %v", err)
+	}
+}
+
+func TestFIPSModeRequiresModule(t *testing.T) {
+	if FIPSEnabled() {
+		t.Skip("crypto module runs in FIPS mode")
+	}
+	_, err := NewHttpClient("https://localhost", WithFIPSMode()).Get()
+	if !errors.Is(err, ErrFIPSViolation) {
+		t.Errorf("Expected ErrFIPSViolation, got %v", err)
+	}
+}
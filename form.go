@@ -0,0 +1,136 @@
+package easyrqst
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// formValues flattens a form payload into url.Values. It accepts map[string]string,
+// url.Values or a struct (or pointer to struct) whose fields are named via `form` tags.
+func formValues(payload any) (url.Values, error) {
+	switch v := payload.(type) {
+	case nil:
+		return url.Values{}, nil
+	case map[string]string:
+		data := url.Values{}
+		for k, val := range v {
+			data.Set(k, val)
+		}
+		return data, nil
+	case url.Values:
+		return v, nil
+	case map[string][]string:
+		return url.Values(v), nil
+	}
+
+	rv := reflect.ValueOf(payload)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return url.Values{}, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unsupported form payload type %T", payload)
+	}
+
+	data := url.Values{}
+	if err := encodeFormStruct(rv, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func encodeFormStruct(rv reflect.Value, data url.Values) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(field.Tag.Get("form"), ",")
+		if name == "-" {
+			continue
+		}
+		fv := rv.Field(i)
+
+		if field.Anonymous && name == "" {
+			for fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					break
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				if err := encodeFormStruct(fv, data); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if opts == "omitempty" && fv.IsZero() {
+			continue
+		}
+
+		values, err := formFieldValues(fv)
+		if err != nil {
+			return fmt.Errorf("form field %s: %v", field.Name, err)
+		}
+		for _, val := range values {
+			data.Add(name, val)
+		}
+	}
+	return nil
+}
+
+func formFieldValues(fv reflect.Value) ([]string, error) {
+	for fv.Kind() == reflect.Pointer || fv.Kind() == reflect.Interface {
+		if fv.IsNil() {
+			return nil, nil
+		}
+		fv = fv.Elem()
+	}
+
+	if t, ok := fv.Interface().(time.Time); ok {
+		return []string{t.Format(time.RFC3339)}, nil
+	}
+	if s, ok := fv.Interface().(fmt.Stringer); ok {
+		return []string{s.String()}, nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		return []string{fv.String()}, nil
+	case reflect.Bool:
+		return []string{strconv.FormatBool(fv.Bool())}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return []string{strconv.FormatInt(fv.Int(), 10)}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return []string{strconv.FormatUint(fv.Uint(), 10)}, nil
+	case reflect.Float32, reflect.Float64:
+		return []string{strconv.FormatFloat(fv.Float(), 'f', -1, fv.Type().Bits())}, nil
+	case reflect.Slice, reflect.Array:
+		var values []string
+		for i := 0; i < fv.Len(); i++ {
+			items, err := formFieldValues(fv.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, items...)
+		}
+		return values, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", fv.Type())
+}
@@ -0,0 +1,62 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type formBase struct {
+	Email string `form:"email"`
+}
+
+type formPayload struct {
+	formBase
+	Name    string   `form:"name"`
+	Age     int      `form:"age"`
+	Tags    []string `form:"tag"`
+	Note    string   `form:"note,omitempty"`
+	Secret  string   `form:"-"`
+	Enabled bool
+}
+
+func TestFormValuesFromStruct(t *testing.T) {
+	data, err := formValues(&formPayload{formBase: formBase{Email: "example@example.com"}, Name: "morpheus", Age: 30, Tags: []string{"a", "b"}, Secret: "x", Enabled: true})
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	expected := "Enabled=true&age=30&email=example%40example.com&name=morpheus&tag=a&tag=b"
+	if data.Encode() != expected {
+		t.Errorf("Expected %s, got %s", expected, data.Encode())
+	}
+}
+
+func TestFormValuesRejectsUnsupported(t *testing.T) {
+	if _, err := formValues([]string{"a"}); err == nil {
+		t.Errorf("Expected error for slice payload")
+	}
+}
+
+func TestPostFormStruct(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(r.FormValue("name") + ":" + r.FormValue("age")))
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL)
+	headers := WithHeaders(map[string]string{"Content-Type": "multipart/form-data"})
+
+	outcome, err := call.Post(WithPayload(formPayload{Name: "morpheus", Age: 30}), headers)
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if string(outcome.Body) != "morpheus:30" {
+		t.Errorf("Expected morpheus:30, got %s", outcome.Body)
+	}
+}
@@ -4,4 +4,4 @@ go 1.22
 
 require github.com/hashicorp/go-retryablehttp v0.7.7
 
-require github.com/hashicorp/go-cleanhttp v0.5.2 // indirect
+require github.com/hashicorp/go-cleanhttp v0.5.2
@@ -0,0 +1,78 @@
+package easyrqst
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Group sends requests concurrently and cancels the ones still running as soon as one
+// fails, like errgroup.
+type Group struct {
+	client    IHttpClient
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	mu        sync.Mutex
+	responses []*HttpResponse
+	err       error
+}
+
+// Group returns an empty group whose requests are canceled along with ctx.
+func (h *easyRequest) Group(ctx context.Context) *Group {
+	return newGroup(h, ctx)
+}
+
+func newGroup(client IHttpClient, ctx context.Context) *Group {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{client: client, ctx: ctx, cancel: cancel}
+}
+
+// Go sends a request in the background with the group context, which replaces any
+// context in opts. A transport error or a status other than 2xx fails the group.
+func (g *Group) Go(method string, opts ...TReqOption) {
+	g.mu.Lock()
+	i := len(g.responses)
+	g.responses = append(g.responses, nil)
+	g.mu.Unlock()
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		response, err := g.client.Custom(method, append(opts[:len(opts):len(opts)], WithContext(g.ctx))...)
+		if err == nil && (response.StatusCode < 200 || response.StatusCode >= 300) {
+			err = fmt.Errorf("unexpected status code %d", response.StatusCode)
+		}
+
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		g.responses[i] = response
+		if err != nil && g.err == nil {
+			g.err = err
+			g.cancel()
+		}
+	}()
+}
+
+// Wait waits for every request and returns the responses in the order of the Go calls,
+// along with the first error. Responses of canceled requests are nil.
+func (g *Group) Wait() ([]*HttpResponse, error) {
+	g.wg.Wait()
+	g.cancel()
+	return g.responses, g.err
+}
+
+// WaitDecoded waits for the requests of g and decodes every response into T.
+func WaitDecoded[T any](g *Group) ([]T, error) {
+	responses, err := g.Wait()
+	if err != nil {
+		return nil, err
+	}
+	results := make([]T, len(responses))
+	for i, response := range responses {
+		if results[i], err = Decode[T](response); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,55 @@
+package easyrqst
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGroup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/slow":
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+		case "/fail":
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.Write([]byte(`{"path": "` + r.URL.Path + `"}`))
+		}
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL)
+	group := call.Group(context.Background())
+	for _, path := range []string{"a", "b", "c"} {
+		group.Go(http.MethodGet, WithPath(path))
+	}
+	results, err := WaitDecoded[struct{ Path string }](group)
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if len(results) != 3 || results[0].Path != "/a" || results[2].Path != "/c" {
+		t.Errorf("Expected results in call order, got %v", results)
+	}
+
+	start := time.Now()
+	group = call.Group(context.Background())
+	group.Go(http.MethodGet, WithPath("slow"))
+	group.Go(http.MethodGet, WithPath("fail"))
+	responses, err := group.Wait()
+	if err == nil {
+		t.Errorf("Expected the failed request to fail the group")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected the slow request to be canceled, waited %v", elapsed)
+	}
+	if responses[0] != nil || responses[1].StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected no slow response and the failed one, got %v", responses)
+	}
+}
@@ -0,0 +1,235 @@
+package easyrqst
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HttpVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HttpVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+type harEntry struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Error           string      `json:"_error,omitempty"`
+}
+
+// HarConfig tells a HarRecorder how much to keep.
+type HarConfig struct {
+	// MaxEntries is the number of entries kept, 1000 by default; the oldest ones are
+	// dropped first.
+	MaxEntries int
+	// OmitCookies leaves the Cookie and Set-Cookie headers out of entries altogether.
+	OmitCookies bool
+}
+
+// HarRecorder captures the traffic of the clients it is attached to with
+// WithHarRecorder, in HTTP Archive 1.2 format for browser devtools and load testing
+// tools. Entries are kept in memory until written out. Credentials are masked in URLs,
+// query strings and headers the way the debug page does, so archives can be attached
+// to support tickets; bodies are recorded as they are.
+type HarRecorder struct {
+	mu      sync.Mutex
+	config  HarConfig
+	entries []harEntry
+}
+
+// NewHarRecorder returns an empty recorder to share between clients with
+// WithHarRecorder.
+func NewHarRecorder(config HarConfig) *HarRecorder {
+	if config.MaxEntries <= 0 {
+		config.MaxEntries = 1000
+	}
+	return &HarRecorder{config: config}
+}
+
+// WithHarRecorder records every attempt of the client and its response into recorder.
+func WithHarRecorder(recorder *HarRecorder) THttpOption {
+	return func(o *easyRequest) { o.har = recorder }
+}
+
+// WriteTo writes the recorded entries as a HAR document.
+func (r *HarRecorder) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	entries := append([]harEntry{}, r.entries...)
+	r.mu.Unlock()
+
+	var doc struct {
+		Log struct {
+			Version string     `json:"version"`
+			Creator harCreator `json:"creator"`
+			Entries []harEntry `json:"entries"`
+		} `json:"log"`
+	}
+	doc.Log.Version = "1.2"
+	doc.Log.Creator = harCreator{Name: "easyrqst", Version: "1.0"}
+	doc.Log.Entries = entries
+
+	byts, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(byts)
+	return int64(n), err
+}
+
+// Save writes the recorded entries to a HAR file at path.
+func (r *HarRecorder) Save(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := r.WriteTo(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func (r *HarRecorder) headers(header http.Header, secret []string) []harNameValue {
+	pairs := []harNameValue{}
+	for name, values := range redactHeader(header, secret...) {
+		if r.config.OmitCookies && (name == "Cookie" || name == "Set-Cookie") {
+			continue
+		}
+		for _, value := range values {
+			pairs = append(pairs, harNameValue{Name: name, Value: value})
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Name < pairs[j].Name })
+	return pairs
+}
+
+// capture starts an entry for req, masking the secret query parameters and headers;
+// the returned function completes it with the response.
+func (r *HarRecorder) capture(req *http.Request, secretParams, secretHeaders []string) func(statusCode int, header http.Header, body []byte, err error) {
+	if r == nil {
+		return func(int, http.Header, []byte, error) {}
+	}
+
+	start := time.Now()
+	entry := harEntry{StartedDateTime: start}
+	redacted := redactURL(req.URL, secretParams...)
+	entry.Request = harRequest{
+		Method:      req.Method,
+		URL:         redacted,
+		HttpVersion: "HTTP/1.1",
+		Cookies:     []harNameValue{},
+		Headers:     r.headers(req.Header, secretHeaders),
+		QueryString: []harNameValue{},
+		HeadersSize: -1,
+	}
+	query := url.Values{}
+	if u, err := url.Parse(redacted); err == nil {
+		query = u.Query()
+	}
+	for name, values := range query {
+		for _, value := range values {
+			entry.Request.QueryString = append(entry.Request.QueryString, harNameValue{Name: name, Value: value})
+		}
+	}
+	if req.GetBody != nil {
+		if reader, err := req.GetBody(); err == nil {
+			body, _ := io.ReadAll(reader)
+			reader.Close()
+			entry.Request.BodySize = len(body)
+			entry.Request.PostData = &harPostData{MimeType: req.Header.Get("Content-Type"), Text: string(body)}
+		}
+	}
+
+	return func(statusCode int, header http.Header, body []byte, err error) {
+		elapsed := float64(time.Since(start).Microseconds()) / 1000
+		entry.Time = elapsed
+		entry.Timings = harTimings{Wait: elapsed}
+		entry.Response = harResponse{
+			Status:      statusCode,
+			StatusText:  http.StatusText(statusCode),
+			HttpVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     r.headers(header, secretHeaders),
+			Content:     harContent{Size: len(body), MimeType: header.Get("Content-Type")},
+			RedirectURL: header.Get("Location"),
+			HeadersSize: -1,
+			BodySize:    len(body),
+		}
+		if utf8.Valid(body) {
+			entry.Response.Content.Text = string(body)
+		} else {
+			entry.Response.Content.Text = base64.StdEncoding.EncodeToString(body)
+			entry.Response.Content.Encoding = "base64"
+		}
+		if err != nil {
+			// Transport errors quote the URL
+			entry.Error = strings.ReplaceAll(err.Error(), req.URL.String(), redacted)
+		}
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if len(r.entries) >= r.config.MaxEntries {
+			r.entries = append(r.entries[:0], r.entries[len(r.entries)-r.config.MaxEntries+1:]...)
+		}
+		r.entries = append(r.entries, entry)
+	}
+}
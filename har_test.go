@@ -0,0 +1,108 @@
+package easyrqst
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHarRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	recorder := NewHarRecorder(HarConfig{})
+	call := NewHttpClient(server.URL, WithHarRecorder(recorder))
+	_, err := call.Post(WithPath("users"), WithQueries(map[string]string{"notify": "true"}), WithPayload(map[string]string{"name": "neo"}))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if _, err := recorder.WriteTo(&buf); err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+
+	var doc struct {
+		Log struct {
+			Version string
+			Entries []struct {
+				Request struct {
+					Method      string
+					URL         string
+					QueryString []struct{ Name, Value string }
+					PostData    struct{ Text string }
+				}
+				Response struct {
+					Status  int
+					Content struct{ Text, MimeType string }
+				}
+			}
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if doc.Log.Version != "1.2" || len(doc.Log.Entries) != 1 {
+		t.Errorf("Expected a single HAR 1.2 entry, got %s", buf.String())
+		return
+	}
+	entry := doc.Log.Entries[0]
+	if entry.Request.Method != http.MethodPost || entry.Request.PostData.Text != `{"name":"neo"}` || len(entry.Request.QueryString) != 1 {
+		t.Errorf("Expected the request recorded, got %+v", entry.Request)
+	}
+	if entry.Response.Status != http.StatusCreated || entry.Response.Content.Text != `{"id":1}` || entry.Response.Content.MimeType != "application/json" {
+		t.Errorf("Expected the response recorded, got %+v", entry.Response)
+	}
+
+	path := filepath.Join(t.TempDir(), "traffic.har")
+	if err := recorder.Save(path); err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if saved, _ := os.ReadFile(path); !bytes.Equal(saved, buf.Bytes()) {
+		t.Errorf("Expected the saved file to match WriteTo")
+	}
+}
+
+func TestHarRecorderRedaction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=s3ss10n")
+	}))
+	defer server.Close()
+
+	recorder := NewHarRecorder(HarConfig{MaxEntries: 2, OmitCookies: true})
+	call := NewHttpClient(server.URL, WithHarRecorder(recorder), WithClientBasicAuth("neo", "matrix"), WithSigner(NewHMACSigner([]byte("k"), HMACConfig{})))
+	for _, page := range []string{"1", "2", "3"} {
+		_, err := call.Get(WithQueries(map[string]string{"page": page}), WithAPIKey("api_key", "s3cr3t", InQuery),
+			WithAPIKey("X-Auth", "h34d3r", InHeader), WithMergedHeaders(map[string]string{"Cookie": "session=s3ss10n"}))
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if _, err := recorder.WriteTo(&buf); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	archive := buf.String()
+	for _, secret := range []string{"s3cr3t", "h34d3r", "bmVvOm1hdHJpeA", "s3ss10n", "Cookie"} {
+		if strings.Contains(archive, secret) {
+			t.Errorf("Expected %s to be left out of the archive, got %s", secret, archive)
+		}
+	}
+	if strings.Count(archive, `"startedDateTime"`) != 2 || strings.Contains(archive, "page=1") || !strings.Contains(archive, "page=3") {
+		t.Errorf("Expected only the last 2 entries to be kept, got %s", archive)
+	}
+}
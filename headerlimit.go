@@ -0,0 +1,64 @@
+package easyrqst
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ResponseHeaderTooLargeError is returned when a response carries more header bytes than
+// allowed by WithMaxResponseHeaderBytes.
+type ResponseHeaderTooLargeError struct {
+	Limit int64
+}
+
+func (e *ResponseHeaderTooLargeError) Error() string {
+	return fmt.Sprintf("response headers exceed %d bytes", e.Limit)
+}
+
+// TooManyResponseHeadersError is returned when a response carries more header lines than
+// allowed by WithMaxResponseHeaders.
+type TooManyResponseHeadersError struct {
+	Limit int
+	Count int
+}
+
+func (e *TooManyResponseHeadersError) Error() string {
+	return fmt.Sprintf("response has %d header lines, limit is %d", e.Count, e.Limit)
+}
+
+// WithMaxResponseHeaderBytes caps the size of response headers, the transport aborts the
+// response once it's reached. Go defaults to 1MB.
+func WithMaxResponseHeaderBytes(n int64) THttpOption {
+	return func(o *easyRequest) { o.ownTransport().MaxResponseHeaderBytes = n }
+}
+
+// WithMaxResponseHeaders caps the number of header lines of a response. Repeated headers
+// count once per value.
+func WithMaxResponseHeaders(n int) THttpOption {
+	return func(o *easyRequest) { o.maxHeaders = n }
+}
+
+// checkHeaderLimits turns a response over the header limits into a typed error. Neither
+// error is retried, the server would send the same headers again.
+func checkHeaderLimits(resp *http.Response, err error, maxBytes int64, maxHeaders int) (*http.Response, error) {
+	if err != nil {
+		// net/http reports the size limit with a plain error
+		if maxBytes > 0 && strings.Contains(err.Error(), "response headers exceeded") {
+			return nil, &ResponseHeaderTooLargeError{Limit: maxBytes}
+		}
+		return nil, err
+	}
+	if maxHeaders <= 0 {
+		return resp, nil
+	}
+	count := 0
+	for _, values := range resp.Header {
+		count += len(values)
+	}
+	if count > maxHeaders {
+		resp.Body.Close()
+		return nil, &TooManyResponseHeadersError{Limit: maxHeaders, Count: count}
+	}
+	return resp, nil
+}
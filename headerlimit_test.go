@@ -0,0 +1,45 @@
+package easyrqst
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestResponseHeaderLimits(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch r.URL.Path {
+		case "/large":
+			w.Header().Set("X-Large", strings.Repeat("a", 8<<10))
+		case "/many":
+			for i := 0; i < 20; i++ {
+				w.Header().Add("X-Many", fmt.Sprint(i))
+			}
+		}
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithMaxResponseHeaderBytes(4<<10), WithMaxResponseHeaders(10))
+	if _, err := call.Get(); err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	var tooLarge *ResponseHeaderTooLargeError
+	if _, err := call.Get(WithPath("large")); !errors.As(err, &tooLarge) || tooLarge.Limit != 4<<10 {
+		t.Errorf("Expected ResponseHeaderTooLargeError, got %v", err)
+	}
+
+	calls = 0
+	var tooMany *TooManyResponseHeadersError
+	if _, err := call.Get(WithPath("many")); !errors.As(err, &tooMany) || tooMany.Count < 20 {
+		t.Errorf("Expected TooManyResponseHeadersError, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected header limit errors not to be retried, got %v calls", calls)
+	}
+}
@@ -0,0 +1,160 @@
+package easyrqst
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HealthCheckConfig configures background health checks of the endpoints of a client.
+type HealthCheckConfig struct {
+	// Path is requested with a GET on every endpoint, a 2xx or 3xx answer is healthy
+	Path string
+	// Interval between checks, 10s by default
+	Interval time.Duration
+	// Timeout of a single check, Interval by default
+	Timeout time.Duration
+	// HealthyThreshold consecutive passing checks mark an endpoint up, 2 by default
+	HealthyThreshold int
+	// UnhealthyThreshold consecutive failing checks mark an endpoint down, 3 by default
+	UnhealthyThreshold int
+	// Context stops the checks when done, as does closing the client
+	Context context.Context
+}
+
+type endpointHealth struct {
+	down   bool
+	passed int
+	failed int
+}
+
+type healthChecker struct {
+	mu        sync.Mutex
+	cfg       HealthCheckConfig
+	client    *http.Client
+	endpoints map[string]*endpointHealth
+	stop      chan struct{}
+	stopOnce  sync.Once
+}
+
+// WithHealthCheck checks the endpoint and its fallbacks in the background. Endpoints
+// marked down are skipped by failover and by client pools.
+func WithHealthCheck(cfg HealthCheckConfig) THttpOption {
+	return func(o *easyRequest) { o.healthCfg = &cfg }
+}
+
+func newHealthChecker(cfg HealthCheckConfig, transport http.RoundTripper, endpoints []string) *healthChecker {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 10 * time.Second
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = cfg.Interval
+	}
+	if cfg.HealthyThreshold <= 0 {
+		cfg.HealthyThreshold = 2
+	}
+	if cfg.UnhealthyThreshold <= 0 {
+		cfg.UnhealthyThreshold = 3
+	}
+	if cfg.Context == nil {
+		cfg.Context = context.Background()
+	}
+
+	checker := &healthChecker{
+		cfg:       cfg,
+		client:    &http.Client{Transport: transport, Timeout: cfg.Timeout},
+		endpoints: make(map[string]*endpointHealth),
+		stop:      make(chan struct{}),
+	}
+	for _, endpoint := range endpoints {
+		checker.endpoints[endpoint] = &endpointHealth{}
+	}
+	return checker
+}
+
+func (c *healthChecker) run() {
+	ticker := time.NewTicker(c.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		c.checkAll()
+		select {
+		case <-ticker.C:
+		case <-c.cfg.Context.Done():
+			return
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+func (c *healthChecker) close() {
+	c.stopOnce.Do(func() { close(c.stop) })
+}
+
+func (c *healthChecker) checkAll() {
+	var wg sync.WaitGroup
+	for endpoint := range c.endpoints {
+		wg.Add(1)
+		go func(endpoint string) {
+			defer wg.Done()
+			c.record(endpoint, c.check(endpoint))
+		}(endpoint)
+	}
+	wg.Wait()
+}
+
+func (c *healthChecker) check(endpoint string) bool {
+	url := strings.TrimRight(endpoint, "/") + "/" + strings.TrimLeft(c.cfg.Path, "/")
+	req, err := http.NewRequestWithContext(c.cfg.Context, http.MethodGet, url, nil)
+	if err != nil {
+		return false
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode < 400
+}
+
+func (c *healthChecker) record(endpoint string, passed bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	health := c.endpoints[endpoint]
+	if passed {
+		health.passed, health.failed = health.passed+1, 0
+		if health.down && health.passed >= c.cfg.HealthyThreshold {
+			health.down = false
+		}
+	} else {
+		health.passed, health.failed = 0, health.failed+1
+		if !health.down && health.failed >= c.cfg.UnhealthyThreshold {
+			health.down = true
+		}
+	}
+}
+
+// up reports whether endpoint is healthy. Endpoints start healthy.
+func (c *healthChecker) up(endpoint string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	health, ok := c.endpoints[endpoint]
+	return !ok || !health.down
+}
+
+// Close stops the background health checks of the client. Requests can still be made
+// afterwards.
+func (h *easyRequest) Close() error {
+	if h.health != nil {
+		h.health.close()
+	}
+	return nil
+}
+
+// healthy reports whether the health checks, if any, consider endpoint up.
+func (h *easyRequest) healthy(endpoint string) bool {
+	return h.health == nil || h.health.up(endpoint)
+}
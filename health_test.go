@@ -0,0 +1,104 @@
+package easyrqst
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newHealthServer(name string, healthy *atomic.Bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" && !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(name))
+	}))
+}
+
+func TestHealthCheckedPool(t *testing.T) {
+	var aHealthy, bHealthy atomic.Bool
+	aHealthy.Store(true)
+	a, b := newHealthServer("a", &aHealthy), newHealthServer("b", &bHealthy)
+	defer a.Close()
+	defer b.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	call := NewHttpClientPool([]string{a.URL, b.URL}, RoundRobin(), WithHealthCheck(HealthCheckConfig{
+		Path: "/health", Interval: 10 * time.Millisecond, HealthyThreshold: 1, UnhealthyThreshold: 1, Context: ctx,
+	}))
+	time.Sleep(30 * time.Millisecond)
+	if counts := poolCounts(t, call, 4); counts["a"] != 4 {
+		t.Errorf("Expected unhealthy endpoint to be skipped, got %v", counts)
+	}
+
+	bHealthy.Store(true)
+	time.Sleep(30 * time.Millisecond)
+	if counts := poolCounts(t, call, 4); counts["b"] != 2 {
+		t.Errorf("Expected recovered endpoint to get traffic again, got %v", counts)
+	}
+}
+
+func TestHealthCheckThresholds(t *testing.T) {
+	checker := newHealthChecker(HealthCheckConfig{Interval: time.Second}, http.DefaultTransport, []string{"primary"})
+	for i, passed := range []bool{false, false, false, true, true} {
+		checker.record("primary", passed)
+		if expected := i < 2 || i == 4; checker.up("primary") != expected {
+			t.Errorf("Check %d: expected up=%v", i, expected)
+		}
+	}
+}
+
+func TestHealthCheckedFailover(t *testing.T) {
+	var primaryHealthy, fallbackHealthy atomic.Bool
+	fallbackHealthy.Store(true)
+	primary, fallback := newHealthServer("primary", &primaryHealthy), newHealthServer("fallback", &fallbackHealthy)
+	defer primary.Close()
+	defer fallback.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	call := NewHttpClient(primary.URL, WithFallbackEndpoints(fallback.URL), WithHealthCheck(HealthCheckConfig{
+		Path: "/health", Interval: 10 * time.Millisecond, UnhealthyThreshold: 1, Context: ctx,
+	}))
+	time.Sleep(30 * time.Millisecond)
+	outcome, err := call.Get()
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if string(outcome.Body) != "fallback" {
+		t.Errorf("Expected unhealthy primary to be skipped, got %s", outcome.Body)
+	}
+}
+
+func TestHealthCheckClose(t *testing.T) {
+	var checks atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		checks.Add(1)
+	}))
+	defer server.Close()
+
+	call := NewHttpClientPool([]string{server.URL}, nil, WithHealthCheck(HealthCheckConfig{Path: "/health", Interval: 5 * time.Millisecond}))
+	time.Sleep(20 * time.Millisecond)
+	if err := call.Close(); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+	stopped := checks.Load()
+	time.Sleep(30 * time.Millisecond)
+	if stopped == 0 || checks.Load() != stopped {
+		t.Errorf("Expected the checks to stop on Close, got %d then %d", stopped, checks.Load())
+	}
+}
+
+func TestHealthCheckDefaultInterval(t *testing.T) {
+	checker := newHealthChecker(HealthCheckConfig{}, http.DefaultTransport, nil)
+	if checker.cfg.Interval != 10*time.Second || checker.cfg.Timeout != 10*time.Second {
+		t.Errorf("Expected 10s defaults, got %v and %v", checker.cfg.Interval, checker.cfg.Timeout)
+	}
+}
@@ -0,0 +1,50 @@
+package easyrqst
+
+import (
+	"net/http"
+	"time"
+)
+
+// HookEvent describes the stage of a request a hook is called for. Attempt counts from
+// 1 and Elapsed runs from the first attempt.
+type HookEvent struct {
+	Request  *http.Request
+	Response *http.Response
+	Err      error
+	Attempt  int
+	Elapsed  time.Duration
+}
+
+// Hooks are called at each stage of a request, any of them may be nil.
+type Hooks struct {
+	// OnRequest is called before every attempt.
+	OnRequest func(HookEvent)
+	// OnResponse is called for every attempt the server answered.
+	OnResponse func(HookEvent)
+	// OnRetry is called with the outcome of an attempt that is about to be retried.
+	OnRetry func(HookEvent)
+	// OnError is called once a request failed for good. Response is not set and Attempt
+	// is the number of attempts made, 0 when none was sent.
+	OnError func(HookEvent)
+}
+
+type hookList []Hooks
+
+// WithHooks registers lifecycle hooks, called in the order they were registered.
+// Hooks run on the request path and should return quickly.
+func WithHooks(hooks Hooks) THttpOption {
+	return func(o *easyRequest) { o.hooks = append(o.hooks, hooks) }
+}
+
+func (l hookList) fire(pick func(Hooks) func(HookEvent), event HookEvent) {
+	for _, hooks := range l {
+		if hook := pick(hooks); hook != nil {
+			hook(event)
+		}
+	}
+}
+
+func onRequest(h Hooks) func(HookEvent)  { return h.OnRequest }
+func onResponse(h Hooks) func(HookEvent) { return h.OnResponse }
+func onRetry(h Hooks) func(HookEvent)    { return h.OnRetry }
+func onError(h Hooks) func(HookEvent)    { return h.OnError }
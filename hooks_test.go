@@ -0,0 +1,58 @@
+package easyrqst
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHooks(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 || r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	var events []string
+	record := func(stage string) func(HookEvent) {
+		return func(e HookEvent) {
+			status := 0
+			if e.Response != nil {
+				status = e.Response.StatusCode
+			}
+			events = append(events, fmt.Sprintf("%s:%d:%d:%v", stage, e.Attempt, status, e.Err != nil))
+			if e.Request == nil || e.Elapsed < 0 {
+				t.Errorf("Expected request and elapsed time for %s", stage)
+			}
+		}
+	}
+	call := NewHttpClient(server.URL, WithRetry(1), WithRetryWaitMax(time.Millisecond), WithRetryWaitMin(time.Millisecond), WithHooks(Hooks{
+		OnRequest:  record("request"),
+		OnResponse: record("response"),
+		OnRetry:    record("retry"),
+		OnError:    record("error"),
+	}))
+
+	if _, err := call.Get(); err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	expected := "request:1:0:false response:1:503:false retry:1:503:false request:2:0:false response:2:200:false"
+	if got := strings.Join(events, " "); got != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+
+	events = nil
+	if _, err := call.Get(WithPath("down")); err == nil {
+		t.Errorf("Expected error")
+	}
+	if last := events[len(events)-1]; last != "error:2:0:true" {
+		t.Errorf("Expected error hook after 2 attempts, got %v", events)
+	}
+}
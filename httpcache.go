@@ -0,0 +1,106 @@
+package easyrqst
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WithHttpCaching makes WithCache behave like an RFC 7234 cache: responses are kept for
+// the freshness lifetime given by Cache-Control and Expires, less their Age, and not
+// at all with no-store or no-cache. The WithCache period only applies to responses
+// without freshness information. A shared cache, e.g. one in Redis serving several
+// users, also skips private responses and those to authorized requests.
+func WithHttpCaching(shared bool) THttpOption {
+	return func(o *easyRequest) {
+		o.httpCaching = true
+		o.sharedCache = shared
+	}
+}
+
+func parseCacheControl(header http.Header) map[string]string {
+	directives := make(map[string]string)
+	for _, value := range header.Values("Cache-Control") {
+		for _, directive := range strings.Split(value, ",") {
+			name, arg, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			if name != "" {
+				directives[strings.ToLower(name)] = strings.Trim(arg, `"`)
+			}
+		}
+	}
+	return directives
+}
+
+func has(directives map[string]string, name string) bool {
+	_, ok := directives[name]
+	return ok
+}
+
+// skipsCache reports whether the request asks not to be answered from the cache.
+func (h *easyRequest) skipsCache(req *http.Request) bool {
+	if !h.httpCaching {
+		return false
+	}
+	directives := parseCacheControl(req.Header)
+	return has(directives, "no-store") || has(directives, "no-cache") || req.Header.Get("Pragma") == "no-cache"
+}
+
+// freshness is how long response can be served from the cache, 0 when it must not be
+// stored. fallback is returned when HTTP caching is off or the response doesn't say.
+func (h *easyRequest) freshness(req *http.Request, response *HttpResponse, fallback time.Duration) time.Duration {
+	if !h.httpCaching {
+		return fallback
+	}
+
+	directives := parseCacheControl(response.Header)
+	if has(directives, "no-store") || has(directives, "no-cache") || has(parseCacheControl(req.Header), "no-store") {
+		return 0
+	}
+	if h.sharedCache {
+		if has(directives, "private") {
+			return 0
+		}
+		authorized := req.Header.Get("Authorization") != ""
+		if authorized && !has(directives, "public") && !has(directives, "s-maxage") && !has(directives, "must-revalidate") {
+			return 0
+		}
+	}
+
+	now := time.Now()
+	date, dateErr := http.ParseTime(response.Header.Get("Date"))
+	if dateErr != nil {
+		date = now
+	}
+
+	var lifetime time.Duration
+	switch {
+	case h.sharedCache && has(directives, "s-maxage"):
+		lifetime = seconds(directives["s-maxage"])
+	case has(directives, "max-age"):
+		lifetime = seconds(directives["max-age"])
+	case response.Header.Get("Expires") != "":
+		// Invalid dates, like "0", mean already expired
+		expires, err := http.ParseTime(response.Header.Get("Expires"))
+		if err != nil {
+			return 0
+		}
+		lifetime = expires.Sub(date)
+	default:
+		return fallback
+	}
+
+	age := seconds(response.Header.Get("Age"))
+	if apparent := now.Sub(date); dateErr == nil && apparent > age {
+		age = apparent
+	}
+	return max(lifetime-age, 0)
+}
+
+func seconds(value string) time.Duration {
+	n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || n < 0 {
+		return 0
+	}
+	return time.Duration(n) * time.Second
+}
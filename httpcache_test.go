@@ -0,0 +1,102 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type expiryCache struct {
+	*mapCache
+	expiries map[string]time.Duration
+}
+
+func (c *expiryCache) Set(key string, value any, expiry time.Duration) (any, error) {
+	c.expiries[key] = expiry
+	return c.mapCache.Set(key, value, expiry)
+}
+
+func TestHttpCachingFreshness(t *testing.T) {
+	date := time.Now().UTC().Format(http.TimeFormat)
+	headers := map[string]map[string]string{
+		"/max-age":  {"Cache-Control": "public, max-age=120"},
+		"/aged":     {"Cache-Control": "max-age=120", "Age": "100"},
+		"/s-maxage": {"Cache-Control": "max-age=120, s-maxage=30"},
+		"/expires":  {"Expires": time.Now().Add(time.Hour).UTC().Format(http.TimeFormat), "Date": date},
+		"/expired":  {"Expires": "0"},
+		"/no-store": {"Cache-Control": "no-store"},
+		"/no-cache": {"Cache-Control": "no-cache"},
+		"/private":  {"Cache-Control": "private, max-age=60"},
+		"/none":     {},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for name, value := range headers[r.URL.Path] {
+			w.Header().Set(name, value)
+		}
+	}))
+	defer server.Close()
+
+	expected := map[bool]map[string]time.Duration{
+		false: {"/max-age": 120 * time.Second, "/aged": 20 * time.Second, "/s-maxage": 120 * time.Second, "/expires": time.Hour, "/private": time.Minute, "/none": 5 * time.Minute},
+		true:  {"/max-age": 120 * time.Second, "/aged": 20 * time.Second, "/s-maxage": 30 * time.Second, "/expires": time.Hour, "/none": 5 * time.Minute},
+	}
+	for shared, expiries := range expected {
+		cache := &expiryCache{mapCache: newMapCache(), expiries: make(map[string]time.Duration)}
+		call := NewHttpClient(server.URL, WithHttpCaching(shared), WithDefaults(WithCache(cache, 5*time.Minute, "")))
+		for path := range headers {
+			if _, err := call.Get(WithPath(path)); err != nil {
+				t.Errorf("Error: %v", err)
+				return
+			}
+		}
+
+		if len(cache.expiries) != len(expiries) {
+			t.Errorf("Expected %d stored responses (shared %v), got %v", len(expiries), shared, cache.expiries)
+		}
+		for path, expiry := range expiries {
+			stored, ok := cache.expiries["GET__"+path+"?"]
+			if !ok || stored > expiry || stored < expiry-2*time.Second {
+				t.Errorf("Expected %s kept for %v (shared %v), got %v", path, expiry, shared, stored)
+			}
+		}
+	}
+}
+
+func TestHttpCachingRequestDirectives(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Cache-Control", "max-age=60")
+	}))
+	defer server.Close()
+
+	cache := newMapCache()
+	call := NewHttpClient(server.URL, WithHttpCaching(false), WithDefaults(WithCache(cache, time.Minute, "")))
+	call.Get()
+	if resp, _ := call.Get(); !resp.FromCache {
+		t.Errorf("Expected a cache hit")
+	}
+	if resp, _ := call.Get(WithHeaders(map[string]string{"Cache-Control": "no-cache"})); resp.FromCache || calls != 2 {
+		t.Errorf("Expected no-cache to go to the origin, got %v after %d calls", resp.FromCache, calls)
+	}
+}
+
+func TestHttpCachingSharedAuthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		if r.URL.Path == "/public" {
+			w.Header().Set("Cache-Control", "public, max-age=60")
+		}
+	}))
+	defer server.Close()
+
+	cache := newMapCache()
+	call := NewHttpClient(server.URL, WithHttpCaching(true), WithDefaults(WithCache(cache, time.Minute, ""),
+		WithHeaders(map[string]string{"Authorization": "Bearer abc"})))
+	call.Get(WithPath("user"))
+	call.Get(WithPath("public"))
+	if keys := cache.keys(); len(keys) != 1 || keys[0] != "GET__/public?" {
+		t.Errorf("Expected only the public response cached, got %v", keys)
+	}
+}
@@ -2,6 +2,7 @@ package easyrqst
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
@@ -12,6 +13,9 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -32,6 +36,20 @@ type IHttpClient interface {
 	Get(opts ...TReqOption) (*HttpResponse, error)
 	Post(opts ...TReqOption) (*HttpResponse, error)
 	Custom(method string, opts ...TReqOption) (*HttpResponse, error)
+	FetchIfChanged(opts ...TReqOption) (FetchState, *HttpResponse, error)
+	Exists(opts ...TReqOption) (bool, error)
+	Schedule(at time.Time, method string, opts ...TReqOption) *ScheduledRequest
+	After(d time.Duration, method string, opts ...TReqOption) *ScheduledRequest
+	Group(ctx context.Context) *Group
+	Explain(method string, opts ...TReqOption) (*Resolution, error)
+	InvalidateCache(keyOrPattern string) error
+	CacheStats() CacheStats
+	Prefetch(onError func(error), opts ...TReqOption)
+	PrefetchAll(concurrency int, requests ...[]TReqOption) error
+	ServeDebug(addr string) error
+	DumpCache(w io.Writer) error
+	LoadCache(r io.Reader) error
+	Close() error
 }
 
 type TReqOption func(*ReqOptions)
@@ -44,39 +62,146 @@ type cacheObj struct {
 }
 
 type ReqOptions struct {
-	queries  map[string]string
-	headers  map[string]string
-	files    map[string]string
-	cacheObj *cacheObj
-	payload  any
+	ctx                context.Context
+	timeout            time.Duration
+	attemptTimeout     time.Duration
+	path               string
+	location           string
+	queries            map[string]string
+	headers            map[string]string
+	files              map[string]string
+	cacheObj           *cacheObj
+	payload            any
+	rawBody            []byte
+	canonical          bool
+	editors            []func(*http.Request) error
+	expect             []func(*HttpResponse) error
+	statuses           map[int]reflect.Type
+	decompress         *int64
+	envelope           *envelopeObj
+	stream             *streamObj
+	apiVersion         string
+	priority           Priority
+	retryNonIdempotent bool
+	injected           map[string]FieldGenerator
+	injectedPayload    map[string]any
+	secretParams       []string
+	secretHeaders      []string
 }
 
 type easyRequest struct {
-	forceCache   bool
-	cacheObj     *cacheObj
-	endpoint     string
-	client       *http.Client
-	maxRetry     int
-	retryWaitMax time.Duration
-	logger       interface{}
+	forceCache       bool
+	initErr          error
+	endpoint         string
+	fallbacks        []string
+	failoverRecheck  time.Duration
+	failover         *failover
+	healthCfg        *HealthCheckConfig
+	health           *healthChecker
+	strict           bool
+	successStatuses  map[int]bool
+	errorDecoders    map[int]func() any
+	flights          *flightGroup
+	stampede         StampedeMode
+	refreshes        *flightGroup
+	client           *http.Client
+	attempts         *attemptTransport
+	maxRetry         int
+	retryWaitMin     time.Duration
+	retryWaitMax     time.Duration
+	retryPolicy      TRetryPolicy
+	backoff          TBackoff
+	retryAfterCap    time.Duration
+	retryBudget      *RetryBudget
+	breaker          *circuitBreaker
+	slots            chan struct{}
+	rejectWhenBusy   bool
+	limiter          ILimiter
+	ignoreRetryAfter bool
+	timeout          time.Duration
+	logger           interface{}
+	sampling         *logSampling
+	traffic          *RedactionRules
+	redactedParams   []string
+	dump             *dumper
+	curl             *curlLogger
+	har              *HarRecorder
+	transport        *http.Transport
+	sharedTransport  bool
+	roundTripper     http.RoundTripper
+	faults           *FaultConfig
+	codec            ICacheCodec
+	maxHeaders       int
+	fips             bool
+	signer           ISigner
+	reauth           TReauthFn
+	namespace        string
+	cacheKeyFn       func(*http.Request) string
+	httpCaching      bool
+	sharedCache      bool
+	revalidation     time.Duration
+	staleWindow      time.Duration
+	negative         *negativeCaching
+	cacheable        map[int]bool
+	jitter           float64
+	cacheCounters    cacheCounters
+	requestIDHeader  string
+	profiles         profileObj
+	defaults         []TReqOption
+	middlewares      []TMiddleware
+	hooks            hookList
+	clock            clockObj
+	validators       validatorStore
+	conditional      bool
+	writes           *writePins
+	versioning       VersionStrategy
+	budget           *latencyBudget
+	metrics          *metricsObj
+	budgetHook       func(BudgetStatus)
+	shedLow          bool
+	debug            atomic.Pointer[debugRecorder]
+	informational    TInformationalHook
+	cacheHook        TCacheHook
+	decoded          *decodedCache
+	cacheIndex       *cacheIndex
+	shadow           shadowObj
 }
 
 type HttpResponse struct {
-	method     string
-	cacheKey   string
-	FromCache  bool
-	StatusCode int
-	Body       []byte
+	method         string
+	url            *url.URL
+	cacheKey       string
+	decoded        *decodedCache
+	strict         bool
+	statuses       map[int]reflect.Type
+	maxAge         time.Duration
+	varied         map[string]string
+	FromCache      bool
+	Stale          bool
+	CachedAt       time.Time
+	StatusCode     int
+	Header         http.Header
+	ArchiveEntry   string
+	APIVersion     string
+	Timings        Timings
+	Attempts       int
+	AttemptResults []AttemptResult `json:"-"`
+	TotalLatency   time.Duration
+	FinalURL       string
+	Intermediate   *HttpResponse
+	Body           []byte
 }
 
-func handleMultipartFormData(payload map[string]string, files map[string]string) (*bytes.Buffer, string, error) {
+func handleMultipartFormData(payload url.Values, files map[string]string) (*bytes.Buffer, string, error) {
 	var b bytes.Buffer
 	writer := multipart.NewWriter(&b)
 
-	for key, val := range payload {
-		err := writer.WriteField(key, val)
-		if err != nil {
-			return nil, "", err
+	for key, vals := range payload {
+		for _, val := range vals {
+			err := writer.WriteField(key, val)
+			if err != nil {
+				return nil, "", err
+			}
 		}
 	}
 
@@ -136,18 +261,68 @@ func toStruct[M any, S any](m M) S {
 	return result
 }
 
+func WithContext(ctx context.Context) TReqOption {
+	return func(o *ReqOptions) { o.ctx = ctx }
+}
+
+// WithTimeout bounds the whole request, retries included.
+func WithTimeout(timeout time.Duration) TReqOption {
+	return func(o *ReqOptions) { o.timeout = timeout }
+}
+
+// WithPath appends path to the client endpoint.
+func WithPath(path string) TReqOption {
+	return func(o *ReqOptions) { o.path = path }
+}
+
+// WithQueries replaces the query parameters set so far, client defaults included.
 func WithQueries(queries map[string]string) TReqOption {
-	return func(o *ReqOptions) { o.queries = queries }
+	return func(o *ReqOptions) { o.queries = copyMap(queries) }
 }
 
+// WithMergedQueries adds queries to the query parameters set so far, overriding those
+// with the same name.
+func WithMergedQueries(queries map[string]string) TReqOption {
+	return func(o *ReqOptions) {
+		for k, v := range queries {
+			o.queries[k] = v
+		}
+	}
+}
+
+// WithHeaders replaces the headers set so far, client defaults included.
 func WithHeaders(headers map[string]string) TReqOption {
-	return func(o *ReqOptions) { o.headers = headers }
+	return func(o *ReqOptions) { o.headers = copyMap(headers) }
+}
+
+// WithMergedHeaders adds headers to the headers set so far, overriding those with the
+// same name.
+func WithMergedHeaders(headers map[string]string) TReqOption {
+	return func(o *ReqOptions) {
+		for k, v := range headers {
+			o.headers[k] = v
+		}
+	}
+}
+
+// copyMap copies m so the request can't change the caller's map, or the other way round.
+func copyMap(m map[string]string) map[string]string {
+	copied := make(map[string]string, len(m))
+	for k, v := range m {
+		copied[k] = v
+	}
+	return copied
 }
 
 func WithPayload(payload any) TReqOption {
 	return func(o *ReqOptions) { o.payload = payload }
 }
 
+// WithRawBody sends body as is, bypassing payload encoding.
+func WithRawBody(body []byte) TReqOption {
+	return func(o *ReqOptions) { o.rawBody = body }
+}
+
 func WithFiles(files map[string]string) TReqOption {
 	return func(o *ReqOptions) { o.files = files }
 }
@@ -166,102 +341,232 @@ func WithRetryWaitMax(wait time.Duration) THttpOption {
 	return func(o *easyRequest) { o.retryWaitMax = wait }
 }
 
+// WithClientTimeout bounds every request made by the client, retries included.
+func WithClientTimeout(timeout time.Duration) THttpOption {
+	return func(o *easyRequest) { o.timeout = timeout }
+}
+
 func WithLogger(logger interface{}) THttpOption {
 	return func(o *easyRequest) { o.logger = logger }
 }
 
+// WithDefaults applies the given request options to every request made by the client,
+// before the options passed to the call itself. Use WithMergedHeaders and
+// WithMergedQueries in calls to add to default headers and queries rather than replace
+// them.
+func WithDefaults(opts ...TReqOption) THttpOption {
+	return func(o *easyRequest) { o.defaults = append(o.defaults, opts...) }
+}
+
+func WithTransport(transport *http.Transport) THttpOption {
+	return func(o *easyRequest) {
+		o.transport = transport
+		o.sharedTransport = false
+	}
+}
+
+// WithRoundTripper sends requests through rt instead of the transport, e.g. a mock in
+// tests. Transport settings such as WithMaxResponseHeaderBytes don't apply to it.
+func WithRoundTripper(rt http.RoundTripper) THttpOption {
+	return func(o *easyRequest) { o.roundTripper = rt }
+}
+
 func NewHttpClient(endpoint string, opts ...THttpOption) IHttpClient {
 	client := retryablehttp.NewClient()
 	easyRqstClient := &easyRequest{
-		endpoint:     endpoint,
-		client:       client.StandardClient(),
-		maxRetry:     3,
-		retryWaitMax: 1 * time.Second,
-		logger:       nil,
+		endpoint:        endpoint,
+		client:          client.StandardClient(),
+		maxRetry:        3,
+		retryWaitMin:    1 * time.Second,
+		retryWaitMax:    1 * time.Second,
+		retryAfterCap:   1 * time.Minute,
+		failoverRecheck: 30 * time.Second,
+		logger:          nil,
+		transport:       client.HTTPClient.Transport.(*http.Transport),
 	}
 	for _, opt := range opts {
 		opt(easyRqstClient)
 	}
+	easyRqstClient.applyProfile()
 	client.RetryMax = easyRqstClient.maxRetry
+	client.RetryWaitMin = easyRqstClient.retryWaitMin
 	client.RetryWaitMax = easyRqstClient.retryWaitMax
+	client.Backoff = easyRqstClient.retryBackoff
 	client.Logger = easyRqstClient.logger
+	if easyRqstClient.traffic != nil {
+		// Traffic logging is meant for production, keep credentials out of the other logs too
+		client.Logger = maskingQueries(easyRqstClient.logger)
+	}
+	if easyRqstClient.sampling != nil {
+		client.Logger = nil
+	}
+	var base http.RoundTripper = easyRqstClient.transport
+	if easyRqstClient.roundTripper != nil {
+		base = easyRqstClient.roundTripper
+	}
+	if faultsEnabled && easyRqstClient.faults != nil {
+		base = newFaultTransport(base, *easyRqstClient.faults)
+	}
+	easyRqstClient.attempts = &attemptTransport{
+		base:           base,
+		breaker:        easyRqstClient.breaker,
+		sign:           easyRqstClient.sign,
+		maxHeaderBytes: easyRqstClient.transport.MaxResponseHeaderBytes,
+		maxHeaders:     easyRqstClient.maxHeaders,
+		hooks:          easyRqstClient.hooks,
+		dump:           easyRqstClient.dump,
+	}
+	client.HTTPClient.Transport = easyRqstClient.attempts
+	client.CheckRetry = easyRqstClient.checkRetry
+	client.RequestLogHook = markAttempt
+	easyRqstClient.client.Timeout = easyRqstClient.timeout
+	// Both clients would follow redirects, the outer one sees what the inner one returns
+	client.HTTPClient.CheckRedirect = easyRqstClient.checkRedirect
+	easyRqstClient.client.CheckRedirect = easyRqstClient.checkRedirect
+	endpoints := append([]string{easyRqstClient.endpoint}, easyRqstClient.fallbacks...)
+	if len(easyRqstClient.fallbacks) > 0 {
+		easyRqstClient.failover = newFailover(endpoints, easyRqstClient.failoverRecheck)
+	}
+	if easyRqstClient.healthCfg != nil {
+		easyRqstClient.health = newHealthChecker(*easyRqstClient.healthCfg, base, endpoints)
+		go easyRqstClient.health.run()
+	}
 
 	return easyRqstClient
 }
 
-func (h *easyRequest) profile(url, method string) func() {
+func (h *easyRequest) profile(req *http.Request) func(*HttpResponse, error) {
+	url, method := req.URL.Path, req.Method
 	start := time.Now()
-	return func() {
-		ms := time.Since(start).String()
+	return func(response *HttpResponse, err error) {
+		status := 0
+		if err == nil {
+			status = response.StatusCode
+		}
+		if !h.sampling.keep(status, err) {
+			return
+		}
+		elapsed := time.Since(start)
+		attempts := stateFrom(req.Context()).attempts
 		switch v := h.logger.(type) {
 		case retryablehttp.LeveledLogger:
-			v.Debug("REQUEST_TIME", "url", url, "method", method, "elapsed", ms)
+			v.Debug("REQUEST_TIME", "url", url, "method", method, "status", status, "attempts", attempts, "elapsed", elapsed)
 		case retryablehttp.Logger:
-			v.Printf("REQUEST_TIME url=%s method=%s elapsed=%v", url, method, ms)
+			v.Printf("REQUEST_TIME url=%s method=%s status=%d attempts=%d elapsed=%v", url, method, status, attempts, elapsed)
 		}
 	}
 }
 
-func (h *easyRequest) prepareRequest(method, endpoint string, opts ...TReqOption) (*http.Request, error) {
+func (h *easyRequest) applyOptions(opts ...TReqOption) ReqOptions {
 	options := ReqOptions{
+		ctx:     context.Background(),
 		queries: make(map[string]string),
 		headers: make(map[string]string),
 	}
 
-	// Apply options
+	for _, opt := range h.defaults {
+		opt(&options)
+	}
 	for _, opt := range opts {
 		opt(&options)
 	}
+	return options
+}
+
+// requestURL is the URL of a request to endpoint with options, before editors such as
+// authentication run.
+func (h *easyRequest) requestURL(endpoint string, options *ReqOptions) (string, error) {
+	endpoint = h.applyVersion(endpoint, options)
+
+	if options.location != "" {
+		// URLs handed out by the server, e.g. the next page, are used as they are
+		endpoint = options.location
+	} else if options.path != "" {
+		endpoint = strings.TrimRight(endpoint, "/") + "/" + strings.TrimLeft(options.path, "/")
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	// Add queries
+	query := u.Query()
+	for k, v := range options.queries {
+		if options.location != "" && query.Has(k) {
+			continue
+		}
+		query.Add(k, v)
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+func (h *easyRequest) prepareRequest(method, endpoint string, opts ...TReqOption) (*http.Request, *ReqOptions, error) {
+	if h.initErr != nil {
+		return nil, nil, h.initErr
+	}
+	if err := h.checkFIPS(); err != nil {
+		return nil, nil, err
+	}
+	options := h.applyOptions(opts...)
+
+	if err := options.checkInjected(); err != nil {
+		return nil, nil, err
+	}
 
 	var body io.Reader
 	// Handle payload based on content type
-	if options.payload != nil || options.files != nil {
+	if options.rawBody != nil {
+		body = bytes.NewReader(options.rawBody)
+	} else if options.payload != nil || options.files != nil {
 		switch options.headers["Content-Type"] {
 
 		case "application/x-www-form-urlencoded":
-			data := url.Values{}
-			if formData, ok := options.payload.(map[string]string); ok {
-				for k, v := range formData {
-					data.Set(k, v)
-				}
-				body = bytes.NewReader([]byte(data.Encode()))
-			} else {
-				return nil, fmt.Errorf("payload should be a map[string]string for x-www-form-urlencoded")
+			data, err := formValues(options.payload)
+			if err != nil {
+				return nil, nil, fmt.Errorf("payload should be a map[string]string or struct for x-www-form-urlencoded: %v", err)
 			}
+			body = bytes.NewReader([]byte(data.Encode()))
 
 		case "multipart/form-data":
-			if _, ok := options.payload.(map[string]string); !ok {
-				return nil, fmt.Errorf("payload should be a map[string]string for multipart/form-data")
+			data, err := formValues(options.payload)
+			if err != nil {
+				return nil, nil, fmt.Errorf("payload should be a map[string]string or struct for multipart/form-data: %v", err)
 			}
-			b, contentType, err := handleMultipartFormData(options.payload.(map[string]string), options.files)
+			b, contentType, err := handleMultipartFormData(data, options.files)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			body = b
 			options.headers["Content-Type"] = contentType
 
 		case "application/xml":
 			if _, ok := options.payload.(map[string]interface{}); !ok {
-				return nil, fmt.Errorf("payload should be a map[string]interface{} for application/xml")
+				return nil, nil, fmt.Errorf("payload should be a map[string]interface{} for application/xml")
 			}
 			byts, err := handleXMLData(options.payload.(map[string]interface{}))
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			body = bytes.NewReader(byts)
 
 		default:
-			byts, err := json.Marshal(options.payload)
+			byts, err := options.jsonPayload()
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			body = bytes.NewReader(byts)
 		}
 	}
 
-	req, err := http.NewRequest(method, endpoint, body)
+	target, err := h.requestURL(endpoint, &options)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequestWithContext(options.ctx, method, target, body)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// Add headers
@@ -274,77 +579,324 @@ func (h *easyRequest) prepareRequest(method, endpoint string, opts ...TReqOption
 		req.Header.Add("Content-Type", "application/json")
 	}
 
-	// Add queries
-	query := req.URL.Query()
-	for k, v := range options.queries {
-		query.Add(k, v)
+	for _, edit := range options.editors {
+		if err := edit(req); err != nil {
+			return nil, nil, err
+		}
 	}
-	req.URL.RawQuery = query.Encode()
 
-	if options.cacheObj != nil && options.cacheObj.fncs != nil {
-		h.cacheObj = options.cacheObj
+	h.injectValidators(req, &options)
+	if h.writes.pinned(req) {
+		req.Header.Set("Cache-Control", "no-cache")
 	}
 
-	return req, nil
+	if err := h.sign(req); err != nil {
+		return nil, nil, err
+	}
+
+	return req, &options, nil
+}
+
+// WithCacheKeyFunc replaces the METHOD_idempotency_path?query cache key with keyFn, e.g.
+// to add the host or a header, or to drop tracking parameters. The idempotency passed to
+// WithCache is not part of custom keys; WithCacheNamespace still prefixes them.
+func WithCacheKeyFunc(keyFn func(*http.Request) string) THttpOption {
+	return func(o *easyRequest) { o.cacheKeyFn = keyFn }
 }
 
-func (h *easyRequest) executeRequest(req *http.Request) (*HttpResponse, error) {
-	defer h.profile(req.URL.Path, req.Method)()
+func (h *easyRequest) cacheKey(req *http.Request, cache *cacheObj) string {
+	key := defaultCacheKey(req, cache)
+	if h.cacheKeyFn != nil {
+		key = h.cacheKeyFn(req)
+	}
+	if h.namespace != "" {
+		key = h.namespace + "_" + key
+	}
+	return key
+}
 
-	if h.cacheObj != nil && h.cacheObj.fncs != nil {
-		key := fmt.Sprintf("%s_%s_%s", req.Method, h.cacheObj.idempotency, fmt.Sprintf("%s?%s", req.URL.Path, req.URL.RawQuery))
-		if cached, err := h.cacheObj.fncs.Get(key); err == nil {
-			data := toStruct[any, *HttpResponse](cached)
+func defaultCacheKey(req *http.Request, cache *cacheObj) string {
+	return fmt.Sprintf("%s_%s_%s", req.Method, cache.idempotency, fmt.Sprintf("%s?%s", req.URL.Path, req.URL.RawQuery))
+}
+
+func (h *easyRequest) executeRequest(req *http.Request, options *ReqOptions) (response *HttpResponse, err error) {
+	req = withState(req, options)
+	done := h.profile(req)
+	defer func() {
+		done(response, err)
+		state := stateFrom(req.Context())
+		if response != nil {
+			response = state.annotate(response)
+		}
+		if err != nil {
+			h.hooks.fire(onError, HookEvent{Request: req, Err: err, Attempt: state.attempts, Elapsed: time.Since(state.start)})
+		}
+	}()
+
+	cache := options.cacheObj
+	if cache != nil && cache.fncs != nil {
+		key := h.cacheKey(req, cache)
+		if h.writes.pinned(req) || h.skipsCache(req) {
+			h.cacheDecision(CacheBypass, key, nil)
+			return h.fetch(req, options)
+		}
+		cached, err := cache.fncs.Get(key)
+		var data *HttpResponse
+		if err == nil {
+			if data, err = h.decodeCached(cached); err != nil {
+				err = fmt.Errorf("failed to decode cache entry: %w", err)
+				h.cacheDecision(CacheError, key, err)
+			} else {
+				data, err = h.variant(cache, key, req, data)
+			}
+		}
+		if err == nil {
+			h.cacheDecision(CacheHit, key, nil)
+			h.metrics.cache(req, true)
 			data.cacheKey = key
+			data.url = req.URL
+			data.Timings = Timings{}
 			data.FromCache = true
-			return data, nil
+			data.decoded = h.decoded
+			data.strict = h.strict
+			if h.servableStale(data, cache.expiry) {
+				data.Stale = true
+				h.refreshInBackground(key, req, options, data)
+				return data, nil
+			}
+			if h.revalidatable(data, cache.expiry) {
+				return h.revalidate(req, options, data)
+			}
+			if data.Stale = h.stale(data, cache.expiry); !data.Stale || h.refreshes.inFlight(key) {
+				return data, nil
+			}
+		} else {
+			h.cacheDecision(CacheMiss, key, err)
+			h.metrics.cache(req, false)
 		}
+		if h.stampede != StampedeNone {
+			return h.refreshes.do(key, func() (*HttpResponse, error) {
+				return h.fetch(req, options)
+			})
+		}
+	} else {
+		h.cacheDecision(CacheBypass, "", nil)
+	}
+
+	if h.flights != nil && (req.Method == http.MethodGet || req.Method == http.MethodHead) {
+		return h.flights.do(h.flightKey(req, options), func() (*HttpResponse, error) {
+			return h.fetch(req, options)
+		})
+	}
+	return h.fetch(req, options)
+}
+
+// fetch sends the request and caches the response.
+func (h *easyRequest) fetch(req *http.Request, options *ReqOptions) (*HttpResponse, error) {
+	cache := options.cacheObj
+	if options.timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), options.timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
 	}
 
-	resp, err := h.client.Do(req)
+	if err := h.shed(options); err != nil {
+		return nil, err
+	}
+
+	release, err := h.acquire(req.Context())
 	if err != nil {
 		return nil, err
 	}
+	defer release()
+
+	if h.limiter != nil {
+		if err := h.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+
+	if h.retryBudget != nil {
+		h.retryBudget.deposit()
+	}
+
+	traced, record := h.recordDebug(h.traceInformational(req), options)
+	traced, measure := withTimings(traced)
+	observe := h.metrics.observe(req)
+	logged := h.logTraffic(req, options)
+	h.curl.log(req)
+	archived := h.har.capture(req, h.secretParams(options), h.secretHeaders(options))
+	start := time.Now()
+	resp, err := h.client.Do(traced)
+	if err != nil {
+		err = classifyTransportError(err)
+		h.trackBudget(start, 0, err)
+		record(0, nil, 0, err)
+		observe(0, err)
+		logged(0, nil, nil, err)
+		archived(0, nil, nil, err)
+		return nil, err
+	}
+	h.trackBudget(start, resp.StatusCode, nil)
+	observe(resp.StatusCode, nil)
 	defer resp.Body.Close()
 
+	if options.stream != nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		err := options.stream.run(resp.Body)
+		record(resp.StatusCode, resp.Header, 0, err)
+		logged(resp.StatusCode, resp.Header, nil, err)
+		archived(resp.StatusCode, resp.Header, nil, err)
+		response := &HttpResponse{method: req.Method, url: req.URL, StatusCode: resp.StatusCode, Header: resp.Header, APIVersion: h.negotiatedVersion(resp, options), Timings: measure(), FinalURL: resp.Request.URL.String()}
+		if err != nil {
+			return response, fmt.Errorf("failed to stream response: %w", err)
+		}
+		return response, nil
+	}
+
 	body, err := io.ReadAll(resp.Body)
+	timings := measure()
+	record(resp.StatusCode, resp.Header, len(body), err)
+	logged(resp.StatusCode, resp.Header, body, err)
+	archived(resp.StatusCode, resp.Header, body, err)
 	if err != nil {
-		return &HttpResponse{method: req.Method, StatusCode: resp.StatusCode}, err
+		return &HttpResponse{method: req.Method, StatusCode: resp.StatusCode, Timings: timings}, err
+	}
+
+	response := &HttpResponse{method: req.Method, url: req.URL, StatusCode: resp.StatusCode, Header: resp.Header, Body: body, strict: h.strict, Timings: timings, FinalURL: resp.Request.URL.String()}
+	response.APIVersion = h.negotiatedVersion(resp, options)
+
+	if options.decompress != nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		if err := response.decompress(*options.decompress); err != nil {
+			return response, fmt.Errorf("failed to decompress response: %w", err)
+		}
 	}
 
-	response := &HttpResponse{method: req.Method, StatusCode: resp.StatusCode, Body: body}
+	if options.envelope != nil {
+		if err := response.unwrap(options.envelope); err != nil {
+			return response, err
+		}
+	}
 
-	if h.cacheObj != nil && h.cacheObj.fncs != nil && (resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusCreated) {
-		response.FromCache = false
-		response.cacheKey = fmt.Sprintf("%s_%s_%s", req.Method, h.cacheObj.idempotency, fmt.Sprintf("%s?%s", req.URL.Path, req.URL.RawQuery))
-		_, err = h.cacheObj.fncs.Set(response.cacheKey, response, h.cacheObj.expiry)
+	if cache != nil && cache.fncs != nil {
+		if expiry, ok := h.cacheTTL(resp.StatusCode, cache.expiry); ok {
+			h.store(req, cache, response, expiry)
+		}
 	}
 
+	h.rememberValidators(req, options, response)
+	h.mirror(req, options, response)
+
 	return response, nil
 }
 
-func (h *easyRequest) Get(opts ...TReqOption) (*HttpResponse, error) {
-	req, err := h.prepareRequest(http.MethodGet, h.endpoint, opts...)
+// store caches response for as long as it stays fresh, fallback when it doesn't say.
+func (h *easyRequest) store(req *http.Request, cache *cacheObj, response *HttpResponse, fallback time.Duration) {
+	names, cacheable := varyNames(response.Header)
+	expiry := h.jittered(h.freshness(req, response, fallback))
+	if !cacheable || (h.httpCaching && expiry <= 0) {
+		return
+	}
+	response.maxAge = expiry
+	response.cacheKey = h.cacheKey(req, cache)
+	response.decoded = h.decoded
+	if h.decoded != nil {
+		h.decoded.forget(response.cacheKey)
+	}
+	response.CachedAt = time.Now()
+	response.varied = nil
+	keys := []string{response.cacheKey}
+	if len(names) > 0 {
+		response.varied = variedValues(req, names)
+		keys = append(keys, variantKey(response.cacheKey, response.varied))
+	}
+	keep := h.keepFor(response, expiry)
+	encoded, err := h.encodeCached(response)
+	for _, key := range keys {
+		if err == nil {
+			_, err = cache.fncs.Set(key, encoded, keep)
+		}
+		if err == nil {
+			h.cacheIndex.track(key, cache.fncs, keep, readResource(req))
+		}
+	}
 	if err != nil {
-		return nil, err
+		h.cacheDecision(CacheError, response.cacheKey, err)
+	} else {
+		h.cacheDecision(CacheStore, response.cacheKey, nil)
 	}
-	return h.executeRequest(req)
 }
 
-func (h *easyRequest) Post(opts ...TReqOption) (*HttpResponse, error) {
-	req, err := h.prepareRequest(http.MethodPost, h.endpoint, opts...)
-	if err != nil {
-		return nil, err
+func (h *easyRequest) do(method string, opts ...TReqOption) (*HttpResponse, error) {
+	if h.failover == nil {
+		response, _, err := h.send(method, h.endpoint, opts...)
+		return response, err
+	}
+
+	var response *HttpResponse
+	var err error
+	for _, endpoint := range h.failover.candidates(h.healthy) {
+		var retryable bool
+		response, retryable, err = h.send(method, endpoint, opts...)
+		// Non-idempotent requests may have been processed, don't send them twice
+		if !h.failover.report(endpoint, response, err) || !retryable {
+			break
+		}
 	}
-	return h.executeRequest(req)
+	return response, err
 }
 
-func (h *easyRequest) Custom(method string, opts ...TReqOption) (*HttpResponse, error) {
-	req, err := h.prepareRequest(method, h.endpoint, opts...)
+// send makes the request to endpoint and reports whether it is safe to send again.
+func (h *easyRequest) send(method, endpoint string, opts ...TReqOption) (*HttpResponse, bool, error) {
+	req, options, err := h.prepareRequest(method, endpoint, opts...)
 	if err != nil {
-		return nil, err
+		return nil, false, err
+	}
+	retryable := options.retryNonIdempotent || idempotent(req)
+
+	response, err := h.handle(req, options)
+	if err == nil && h.adjustSkew(response) {
+		// Clock was off, prepare (and sign) the request again with the corrected time
+		req, options, err = h.prepareRequest(method, endpoint, opts...)
+		if err != nil {
+			return nil, false, err
+		}
+		response, err = h.handle(req, options)
+	}
+	if err == nil {
+		var replay bool
+		if replay, err = h.reauthenticate(options.ctx, response); err != nil {
+			return response, retryable, err
+		}
+		if replay {
+			if req, options, err = h.prepareRequest(method, endpoint, opts...); err != nil {
+				return nil, false, err
+			}
+			response, err = h.handle(req, options)
+		}
 	}
-	return h.executeRequest(req)
+	if err == nil {
+		h.writes.record(req, response)
+		h.invalidateWritten(req, response)
+	}
+	if err == nil && response.StatusCode == http.StatusSeeOther {
+		response, err = h.followSeeOther(req, options, response)
+	}
+	if err == nil {
+		response.statuses = options.statuses
+	}
+	return response, retryable, err
+}
+
+func (h *easyRequest) Get(opts ...TReqOption) (*HttpResponse, error) {
+	return h.checkStatus(http.MethodGet, opts...)
+}
+
+func (h *easyRequest) Post(opts ...TReqOption) (*HttpResponse, error) {
+	return h.checkStatus(http.MethodPost, opts...)
+}
+
+func (h *easyRequest) Custom(method string, opts ...TReqOption) (*HttpResponse, error) {
+	return h.checkStatus(method, opts...)
 }
 
 func (h *HttpResponse) Method() string {
@@ -354,3 +906,13 @@ func (h *HttpResponse) Method() string {
 func (h *HttpResponse) CacheKey() string {
 	return h.cacheKey
 }
+
+// clone returns a shallow copy of the response, for callers sharing one response to
+// set their own fields on.
+func (h *HttpResponse) clone() *HttpResponse {
+	if h == nil {
+		return nil
+	}
+	c := *h
+	return &c
+}
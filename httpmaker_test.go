@@ -2,6 +2,8 @@ package easyrqst
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 )
@@ -129,3 +131,35 @@ func TestPostFormUrlencodedRoute(t *testing.T) {
 	}
 	t.Log(string(outcome.Body))
 }
+
+func TestReplaceAndMergeOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s|%s|%s", r.Header.Get("X-Client"), r.Header.Get("X-Call"), r.URL.RawQuery)
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithDefaults(
+		WithHeaders(map[string]string{"X-Client": "easyrqst"}),
+		WithQueries(map[string]string{"v": "1"}),
+	))
+	headers := map[string]string{"X-Call": "report"}
+	cases := []struct {
+		opts     []TReqOption
+		expected string
+	}{
+		{[]TReqOption{WithHeaders(headers), WithQueries(map[string]string{"page": "2"})}, "|report|page=2"},
+		{[]TReqOption{WithMergedHeaders(headers), WithMergedQueries(map[string]string{"page": "2"})}, "easyrqst|report|page=2&v=1"},
+	}
+	for _, c := range cases {
+		outcome, err := call.Get(c.opts...)
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+		if string(outcome.Body) != c.expected {
+			t.Errorf("Expected %s, got %s", c.expected, outcome.Body)
+		}
+	}
+	if len(headers) != 1 {
+		t.Errorf("Expected the caller's map to be left alone, got %v", headers)
+	}
+}
@@ -0,0 +1,48 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptrace"
+	"net/textproto"
+	"strings"
+)
+
+// TInformationalHook receives every 1xx response (e.g. 103 Early Hints) that precedes
+// the final response of a request.
+type TInformationalHook func(req *http.Request, code int, header http.Header)
+
+func WithInformationalHook(hook TInformationalHook) THttpOption {
+	return func(o *easyRequest) { o.informational = hook }
+}
+
+// PreloadLinks returns the targets of `rel=preload` Link headers, as sent with 103 Early Hints.
+func PreloadLinks(header http.Header) []string {
+	var links []string
+	for _, value := range header.Values("Link") {
+		for _, link := range strings.Split(value, ",") {
+			parts := strings.Split(link, ";")
+			target := strings.Trim(strings.TrimSpace(parts[0]), "<>")
+			for _, param := range parts[1:] {
+				name, val, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if strings.EqualFold(name, "rel") && strings.Contains(strings.ToLower(strings.Trim(val, `"`)), "preload") {
+					links = append(links, target)
+					break
+				}
+			}
+		}
+	}
+	return links
+}
+
+func (h *easyRequest) traceInformational(req *http.Request) *http.Request {
+	if h.informational == nil {
+		return req
+	}
+	trace := &httptrace.ClientTrace{
+		Got1xxResponse: func(code int, header textproto.MIMEHeader) error {
+			h.informational(req, code, http.Header(header))
+			return nil
+		},
+	}
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+}
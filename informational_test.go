@@ -0,0 +1,39 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestInformationalHook(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Link", "</style.css>; rel=preload; as=style, </app.js>; rel=preload; as=script")
+		w.WriteHeader(http.StatusEarlyHints)
+		w.Write([]byte("done"))
+	}))
+	defer server.Close()
+
+	var codes []int
+	var links []string
+	call := NewHttpClient(server.URL, WithInformationalHook(func(req *http.Request, code int, header http.Header) {
+		codes = append(codes, code)
+		links = append(links, PreloadLinks(header)...)
+	}))
+
+	outcome, err := call.Get()
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if outcome.StatusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", outcome.StatusCode)
+	}
+	if !reflect.DeepEqual(codes, []int{http.StatusEarlyHints}) {
+		t.Errorf("Expected a single 103 response, got %v", codes)
+	}
+	if !reflect.DeepEqual(links, []string{"/style.css", "/app.js"}) {
+		t.Errorf("Expected preload links, got %v", links)
+	}
+}
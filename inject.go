@@ -0,0 +1,182 @@
+package easyrqst
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// FieldGenerator produces the value of a field injected into JSON payloads. By default
+// the value is generated once per request and reused by its retries.
+type FieldGenerator struct {
+	generate   func() any
+	perAttempt bool
+}
+
+// GeneratedField injects the value returned by generate.
+func GeneratedField(generate func() any) FieldGenerator {
+	return FieldGenerator{generate: generate}
+}
+
+// PerAttempt regenerates the value for every retry instead of reusing it.
+func (g FieldGenerator) PerAttempt() FieldGenerator {
+	g.perAttempt = true
+	return g
+}
+
+// UUIDField injects a random (version 4) UUID.
+func UUIDField() FieldGenerator {
+	return GeneratedField(func() any { return newUUID() })
+}
+
+// ULIDField injects a ULID, which sorts by creation time.
+func ULIDField() FieldGenerator {
+	return GeneratedField(func() any { return newULID(time.Now()) })
+}
+
+// TimestampField injects the current time formatted with layout, or as Unix seconds
+// when layout is empty.
+func TimestampField(layout string) FieldGenerator {
+	return GeneratedField(func() any {
+		if layout == "" {
+			return time.Now().Unix()
+		}
+		return time.Now().Format(layout)
+	})
+}
+
+// WithInjectedFields sets generated top-level fields on the JSON payload right before
+// it is encoded. The payload must encode to a JSON object; raw, form and XML bodies are
+// rejected and requests without a payload are sent as they are.
+func WithInjectedFields(fields map[string]FieldGenerator) TReqOption {
+	return func(o *ReqOptions) {
+		if o.injected == nil {
+			o.injected = make(map[string]FieldGenerator)
+		}
+		for name, generator := range fields {
+			o.injected[name] = generator
+		}
+	}
+}
+
+func newUUID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	s := hex.EncodeToString(b[:])
+	return s[0:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+}
+
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+func newULID(now time.Time) string {
+	var b [16]byte
+	ms := uint64(now.UnixMilli())
+	for i := 5; i >= 0; i-- {
+		b[i] = byte(ms)
+		ms >>= 8
+	}
+	rand.Read(b[6:])
+
+	// 128 bits as 26 base32 characters, the first one carrying the top 3 bits
+	out := make([]byte, 26)
+	var acc uint32
+	bits := 2
+	pos := 0
+	for _, v := range b {
+		acc = acc<<8 | uint32(v)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			out[pos] = crockford[(acc>>bits)&31]
+			pos++
+		}
+	}
+	return string(out)
+}
+
+// jsonPayload encodes the payload with its injected fields. Fields generated once are
+// kept in options so retries can reuse them.
+func (o *ReqOptions) jsonPayload() ([]byte, error) {
+	if len(o.injected) == 0 {
+		return o.marshal(o.payload)
+	}
+
+	fields := make(map[string]any)
+	if o.payload != nil {
+		byts, err := json.Marshal(o.payload)
+		if err != nil {
+			return nil, err
+		}
+		decoder := json.NewDecoder(bytes.NewReader(byts))
+		decoder.UseNumber()
+		if err := decoder.Decode(&fields); err != nil {
+			return nil, fmt.Errorf("injected fields need a payload encoding to a JSON object: %v", err)
+		}
+	}
+	for name, generator := range o.injected {
+		fields[name] = generator.generate()
+	}
+	o.injectedPayload = fields
+	return o.marshal(fields)
+}
+
+func (o *ReqOptions) marshal(v any) ([]byte, error) {
+	if o.canonical {
+		return CanonicalJSON(v)
+	}
+	return json.Marshal(v)
+}
+
+// checkInjected rejects injected fields for bodies that aren't encoded as JSON.
+func (o *ReqOptions) checkInjected() error {
+	if len(o.injected) == 0 {
+		return nil
+	}
+	if o.rawBody != nil {
+		return errors.New("injected fields can't be set on a raw body")
+	}
+	switch contentType := o.headers["Content-Type"]; contentType {
+	case "application/x-www-form-urlencoded", "multipart/form-data", "application/xml":
+		return fmt.Errorf("injected fields need a JSON payload, not %s", contentType)
+	}
+	return nil
+}
+
+// regenerate re-encodes the payload of a retry with fresh per-attempt fields. Only JSON
+// payloads the fields were injected into are regenerated.
+func (o *ReqOptions) regenerate(req *http.Request) (bool, error) {
+	if o.injectedPayload == nil {
+		return false, nil
+	}
+	fields := make(map[string]any, len(o.injectedPayload))
+	regenerated := false
+	for name, value := range o.injectedPayload {
+		fields[name] = value
+	}
+	for name, generator := range o.injected {
+		if generator.perAttempt {
+			fields[name] = generator.generate()
+			regenerated = true
+		}
+	}
+	if !regenerated {
+		return false, nil
+	}
+
+	byts, err := o.marshal(fields)
+	if err != nil {
+		return false, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(byts))
+	req.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(byts)), nil }
+	req.ContentLength = int64(len(byts))
+	return true, nil
+}
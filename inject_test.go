@@ -0,0 +1,92 @@
+package easyrqst
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestInjectedFields(t *testing.T) {
+	var bodies []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var fields map[string]any
+		json.Unmarshal(body, &fields)
+		bodies = append(bodies, fields)
+		if len(bodies) < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	attempt := 0
+	call := NewHttpClient(server.URL, WithRetryWaitMin(time.Millisecond))
+	_, err := call.Post(WithRetryNonIdempotent(), WithPayload(map[string]any{"amount": 10}), WithInjectedFields(map[string]FieldGenerator{
+		"id":      UUIDField(),
+		"attempt": GeneratedField(func() any { attempt++; return attempt }).PerAttempt(),
+	}))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if len(bodies) != 2 {
+		t.Errorf("Expected 2 attempts, got %v", len(bodies))
+		return
+	}
+	if bodies[0]["id"] != bodies[1]["id"] || bodies[0]["amount"] != 10.0 {
+		t.Errorf("Expected id to be reused across attempts, got %v", bodies)
+	}
+	if bodies[0]["attempt"] != 1.0 || bodies[1]["attempt"] != 2.0 {
+		t.Errorf("Expected per-attempt field to be regenerated, got %v", bodies)
+	}
+}
+
+func TestGeneratedIds(t *testing.T) {
+	if id := newUUID(); !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(id) {
+		t.Errorf("Invalid UUID %s", id)
+	}
+	now := time.UnixMilli(1469918176385)
+	id := newULID(now)
+	if len(id) != 26 || id[:10] != "01ARYZ6S41" {
+		t.Errorf("Expected ULID time prefix 01ARYZ6S41, got %s", id)
+	}
+	if later := newULID(now.Add(time.Millisecond)); later[:10] <= id[:10] {
+		t.Errorf("Expected ULIDs to sort by time, got %s before %s", id, later)
+	}
+}
+
+func TestInjectedFieldsNonJSON(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithRetryWaitMin(time.Millisecond))
+	fields := WithInjectedFields(map[string]FieldGenerator{"id": UUIDField().PerAttempt()})
+	if _, err := call.Custom(http.MethodPut, WithRawBody([]byte(`{"a":1}`)), fields); err == nil {
+		t.Error("Expected injected fields on a raw body to be rejected")
+	}
+	form := WithHeaders(map[string]string{"Content-Type": "application/x-www-form-urlencoded"})
+	if _, err := call.Custom(http.MethodPut, form, WithPayload(map[string]string{"a": "1"}), fields); err == nil {
+		t.Error("Expected injected fields on a form body to be rejected")
+	}
+	if len(bodies) != 0 {
+		t.Fatalf("Expected rejected requests not to be sent, got %q", bodies)
+	}
+
+	if _, err := call.Custom(http.MethodPut, fields); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if len(bodies) != 2 || bodies[0] != "" || bodies[1] != "" {
+		t.Errorf("Expected retries of a request without payload to stay empty, got %q", bodies)
+	}
+}
@@ -0,0 +1,112 @@
+package easyrqst
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// InvalidateCache deletes the cached response stored under key, or under every key
+// matching a pattern where * stands for any run of characters, e.g. "GET_*_/users/*".
+// Patterns match the responses this client cached and need WithCacheIndex; an exact
+// key is also deleted from the default cache set with WithDefaults(WithCache(...)).
+func (h *easyRequest) InvalidateCache(keyOrPattern string) error {
+	match := func(key string) bool { return key == keyOrPattern }
+	if strings.Contains(keyOrPattern, "*") {
+		if h.cacheIndex == nil {
+			return errors.New("invalidating cache patterns needs WithCacheIndex")
+		}
+		parts := strings.Split(keyOrPattern, "*")
+		for i, part := range parts {
+			parts[i] = regexp.QuoteMeta(part)
+		}
+		pattern := regexp.MustCompile("^" + strings.Join(parts, ".*") + "$")
+		match = pattern.MatchString
+	}
+
+	err := h.invalidate(func(key string, _ indexedEntry) bool { return match(key) })
+	if strings.Contains(keyOrPattern, "*") {
+		return err
+	}
+	if cache := h.applyOptions().cacheObj; cache != nil && cache.fncs != nil {
+		if deleteErr := cache.fncs.Delete(keyOrPattern); deleteErr == nil {
+			h.forgetDecoded(keyOrPattern)
+		}
+	}
+	return err
+}
+
+// invalidateWritten deletes the cached reads of a resource, and of the resources below
+// it, after a successful write to it. Only reads known to the cache index are deleted.
+func (h *easyRequest) invalidateWritten(req *http.Request, response *HttpResponse) {
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return
+	}
+	switch req.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return
+	}
+
+	written := resourceOf(req)
+	h.invalidate(func(_ string, entry indexedEntry) bool {
+		return entry.resource != "" && (entry.resource == written || strings.HasPrefix(entry.resource, written+"/"))
+	})
+}
+
+func (h *easyRequest) invalidate(match func(key string, entry indexedEntry) bool) error {
+	var errs []error
+	for _, entry := range h.cacheIndex.matching(match) {
+		key := entry.key
+		if err := entry.cache.Delete(key); err != nil {
+			// Still indexed, so it can be invalidated again
+			h.cacheDecision(CacheError, key, err)
+			errs = append(errs, err)
+			continue
+		}
+		h.cacheIndex.forget(entry)
+		h.forgetDecoded(key)
+		h.cacheDecision(CacheInvalidate, key, nil)
+	}
+	return errors.Join(errs...)
+}
+
+func (h *easyRequest) forgetDecoded(key string) {
+	if h.decoded != nil {
+		h.decoded.forget(key)
+	}
+}
+
+// readResource is the resource of requests whose cached responses writes invalidate.
+func readResource(req *http.Request) string {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return ""
+	}
+	return resourceOf(req)
+}
+
+func (c *cacheIndex) matching(match func(key string, entry indexedEntry) bool) []*indexedEntry {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var matched []*indexedEntry
+	for el := c.order.Front(); el != nil; el = el.Next() {
+		if entry := el.Value.(*indexedEntry); match(entry.key, *entry) {
+			matched = append(matched, entry)
+		}
+	}
+	return matched
+}
+
+// forget drops entry from the index, unless its key was stored again since.
+func (c *cacheIndex) forget(entry *indexedEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[entry.key]; ok && el.Value.(*indexedEntry) == entry {
+		c.order.Remove(el)
+		delete(c.items, entry.key)
+	}
+}
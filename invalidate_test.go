@@ -0,0 +1,109 @@
+package easyrqst
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestInvalidateCache(t *testing.T) {
+	calls := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+	}))
+	defer server.Close()
+
+	cache := newMapCache()
+	var invalidated []string
+	call := NewHttpClient(server.URL, WithCacheIndex(0), WithDefaults(WithCache(cache, time.Minute, "v1")),
+		WithCacheHook(func(decision CacheDecision, key string, err error) {
+			if decision == CacheInvalidate {
+				invalidated = append(invalidated, key)
+			}
+		}))
+	for _, path := range []string{"users/1", "users/2", "teams/1"} {
+		call.Get(WithPath(path))
+	}
+
+	if err := call.InvalidateCache("GET_v1_/users/*"); err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if len(invalidated) != 2 || len(cache.keys()) != 1 {
+		t.Errorf("Expected the users entries invalidated, got %v and %v left", invalidated, cache.keys())
+	}
+
+	if err := call.InvalidateCache("GET_v1_/teams/1?"); err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if len(cache.keys()) != 0 {
+		t.Errorf("Expected the exact key invalidated, got %v left", cache.keys())
+	}
+
+	call.Get(WithPath("users/1"))
+	if calls["/users/1"] != 2 {
+		t.Errorf("Expected an invalidated entry to be refetched, got %d calls", calls["/users/1"])
+	}
+}
+
+func TestWritesInvalidateCachedReads(t *testing.T) {
+	calls := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.Method+" "+r.URL.Path]++
+		if r.URL.Path == "/users/3" && r.Method == http.MethodPut {
+			w.WriteHeader(http.StatusConflict)
+		}
+	}))
+	defer server.Close()
+
+	cache := newMapCache()
+	call := NewHttpClient(server.URL, WithCacheIndex(0), WithDefaults(WithCache(cache, time.Minute, "v1")))
+	for _, path := range []string{"users/1", "users/1/posts", "users/2", "users/3"} {
+		call.Get(WithPath(path))
+	}
+
+	call.Custom(http.MethodPut, WithPath("users/1"), WithPayload(map[string]string{"name": "neo"}))
+	call.Custom(http.MethodPut, WithPath("users/3"), WithPayload(map[string]string{"name": "smith"}))
+	for _, path := range []string{"users/1", "users/1/posts", "users/2", "users/3"} {
+		call.Get(WithPath(path))
+	}
+
+	expected := map[string]int{"GET /users/1": 2, "GET /users/1/posts": 2, "GET /users/2": 1, "GET /users/3": 1}
+	for request, count := range expected {
+		if calls[request] != count {
+			t.Errorf("Expected %s %d times, got %d", request, count, calls[request])
+		}
+	}
+}
+
+type flakyDeleteCache struct {
+	*mapCache
+	fail bool
+}
+
+func (c *flakyDeleteCache) Delete(key string) error {
+	if c.fail {
+		return errors.New("cache unavailable")
+	}
+	return c.mapCache.Delete(key)
+}
+
+func TestInvalidateCacheDeleteError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	cache := &flakyDeleteCache{mapCache: newMapCache(), fail: true}
+	call := NewHttpClient(server.URL, WithCacheIndex(0), WithDefaults(WithCache(cache, time.Minute, "v1")))
+	call.Get(WithPath("users/1"))
+
+	if err := call.InvalidateCache("GET_v1_/users/*"); err == nil {
+		t.Errorf("Expected the delete error")
+	}
+	cache.fail = false
+	if err := call.InvalidateCache("GET_v1_/users/*"); err != nil || len(cache.keys()) != 0 {
+		t.Errorf("Expected the entry to be invalidated once the cache is back, got %v and %v left", err, cache.keys())
+	}
+}
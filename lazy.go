@@ -0,0 +1,274 @@
+package easyrqst
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// THttpFactory builds a client, typically doing expensive setup such as DNS warm-up,
+// TLS session establishment or fetching credentials.
+type THttpFactory func() (IHttpClient, error)
+
+// delegateClient forwards every call to the client returned by resolve, and calls the
+// returned release function once the call is done. Close closes every client returned
+// by clients.
+type delegateClient struct {
+	resolve func() (IHttpClient, func(), error)
+	clients func() []IHttpClient
+}
+
+func noRelease() {}
+
+// NewLazyHttpClient defers calling factory until the first request. Concurrent first
+// requests share a single initialization; a failed initialization is retried on the
+// next request.
+func NewLazyHttpClient(factory THttpFactory) IHttpClient {
+	var mu sync.Mutex
+	var client IHttpClient
+	return &delegateClient{resolve: func() (IHttpClient, func(), error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if client != nil {
+			return client, noRelease, nil
+		}
+		c, err := factory()
+		if err != nil {
+			return nil, nil, err
+		}
+		client = c
+		return client, noRelease, nil
+	}, clients: func() []IHttpClient {
+		mu.Lock()
+		defer mu.Unlock()
+		if client == nil {
+			return nil
+		}
+		return []IHttpClient{client}
+	}}
+}
+
+// NewWarmHttpPool initializes size clients in the background right away and spreads
+// requests over them round-robin, so bursts don't pay for the setup. Requests made
+// before any client is ready wait for the first one. Clients that failed to initialize
+// are retried one at a time on later requests.
+func NewWarmHttpPool(factory THttpFactory, size int) IHttpClient {
+	if size < 1 {
+		size = 1
+	}
+
+	var mu sync.Mutex
+	var ready []IHttpClient
+	var lastErr error
+	pending, failed := 0, 0
+	cond := sync.NewCond(&mu)
+
+	// start initializes a client in the background; callers hold mu.
+	start := func() {
+		pending++
+		go func() {
+			c, err := factory()
+			mu.Lock()
+			defer mu.Unlock()
+			pending--
+			if err != nil {
+				failed++
+				lastErr = err
+			} else {
+				ready = append(ready, c)
+			}
+			cond.Broadcast()
+		}()
+	}
+	mu.Lock()
+	for i := 0; i < size; i++ {
+		start()
+	}
+	mu.Unlock()
+
+	var next atomic.Uint64
+	return &delegateClient{resolve: func() (IHttpClient, func(), error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if failed > 0 && pending == 0 {
+			failed--
+			start()
+		}
+		for len(ready) == 0 && pending > 0 {
+			cond.Wait()
+		}
+		if len(ready) == 0 {
+			return nil, nil, lastErr
+		}
+		return ready[next.Add(1)%uint64(len(ready))], noRelease, nil
+	}, clients: func() []IHttpClient {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]IHttpClient(nil), ready...)
+	}}
+}
+
+func (d *delegateClient) Get(opts ...TReqOption) (*HttpResponse, error) {
+	client, release, err := d.resolve()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return client.Get(opts...)
+}
+
+func (d *delegateClient) Post(opts ...TReqOption) (*HttpResponse, error) {
+	client, release, err := d.resolve()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return client.Post(opts...)
+}
+
+func (d *delegateClient) Custom(method string, opts ...TReqOption) (*HttpResponse, error) {
+	client, release, err := d.resolve()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return client.Custom(method, opts...)
+}
+
+func (d *delegateClient) FetchIfChanged(opts ...TReqOption) (FetchState, *HttpResponse, error) {
+	client, release, err := d.resolve()
+	if err != nil {
+		return FetchError, nil, err
+	}
+	defer release()
+	return client.FetchIfChanged(opts...)
+}
+
+func (d *delegateClient) Exists(opts ...TReqOption) (bool, error) {
+	client, release, err := d.resolve()
+	if err != nil {
+		return false, err
+	}
+	defer release()
+	return client.Exists(opts...)
+}
+
+// Schedule resolves the client when the request fires, not when it's scheduled.
+func (d *delegateClient) Schedule(at time.Time, method string, opts ...TReqOption) *ScheduledRequest {
+	return schedule(at, func() (*HttpResponse, error) { return d.Custom(method, opts...) })
+}
+
+func (d *delegateClient) After(dur time.Duration, method string, opts ...TReqOption) *ScheduledRequest {
+	return d.Schedule(time.Now().Add(dur), method, opts...)
+}
+
+func (d *delegateClient) Group(ctx context.Context) *Group {
+	return newGroup(d, ctx)
+}
+
+func (d *delegateClient) Explain(method string, opts ...TReqOption) (*Resolution, error) {
+	client, release, err := d.resolve()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return client.Explain(method, opts...)
+}
+
+// each calls fn with every client, once the client can be resolved.
+func (d *delegateClient) each(fn func(IHttpClient) error) error {
+	_, release, err := d.resolve()
+	if err != nil {
+		return err
+	}
+	release()
+	var errs []error
+	for _, client := range d.clients() {
+		errs = append(errs, fn(client))
+	}
+	return errors.Join(errs...)
+}
+
+// InvalidateCache invalidates keyOrPattern in the cache of every client.
+func (d *delegateClient) InvalidateCache(keyOrPattern string) error {
+	return d.each(func(client IHttpClient) error { return client.InvalidateCache(keyOrPattern) })
+}
+
+// CacheStats adds up the counters of every client. They are zero while the client
+// can't be resolved.
+func (d *delegateClient) CacheStats() CacheStats {
+	var total CacheStats
+	d.each(func(client IHttpClient) error {
+		stats := client.CacheStats()
+		total.Hits += stats.Hits
+		total.Misses += stats.Misses
+		total.Bypasses += stats.Bypasses
+		total.Stores += stats.Stores
+		total.Revalidations += stats.Revalidations
+		total.Invalidations += stats.Invalidations
+		total.Errors += stats.Errors
+		return nil
+	})
+	return total
+}
+
+func (d *delegateClient) Prefetch(onError func(error), opts ...TReqOption) {
+	go func() {
+		if err := d.prefetch(opts...); err != nil && onError != nil {
+			onError(err)
+		}
+	}()
+}
+
+func (d *delegateClient) PrefetchAll(concurrency int, requests ...[]TReqOption) error {
+	return prefetchAll(d.prefetch, concurrency, requests)
+}
+
+func (d *delegateClient) prefetch(opts ...TReqOption) error {
+	client, release, err := d.resolve()
+	if err != nil {
+		return err
+	}
+	defer release()
+	return client.PrefetchAll(1, opts)
+}
+
+// ServeDebug serves the debug page of a single client, the next one in turn for pools.
+func (d *delegateClient) ServeDebug(addr string) error {
+	client, release, err := d.resolve()
+	if err != nil {
+		return err
+	}
+	defer release()
+	return client.ServeDebug(addr)
+}
+
+// DumpCache writes the cached responses of every client, one after the other.
+func (d *delegateClient) DumpCache(w io.Writer) error {
+	return d.each(func(client IHttpClient) error { return client.DumpCache(w) })
+}
+
+// LoadCache loads the responses into the cache of a single client, the next one in turn
+// for pools; clients sharing a cache all see them.
+func (d *delegateClient) LoadCache(r io.Reader) error {
+	client, release, err := d.resolve()
+	if err != nil {
+		return err
+	}
+	defer release()
+	return client.LoadCache(r)
+}
+
+func (d *delegateClient) Close() error {
+	if d.clients == nil {
+		return nil
+	}
+	var errs []error
+	for _, client := range d.clients() {
+		errs = append(errs, client.Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,104 @@
+package easyrqst
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLazyHttpClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	var built atomic.Int32
+	fail := true
+	call := NewLazyHttpClient(func() (IHttpClient, error) {
+		if fail {
+			fail = false
+			return nil, errors.New("dns not ready")
+		}
+		built.Add(1)
+		return NewHttpClient(server.URL), nil
+	})
+	if built.Load() != 0 {
+		t.Errorf("Expected factory not to run before first use")
+	}
+
+	if _, err := call.Get(); err == nil {
+		t.Errorf("Expected initialization error")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := call.Get(); err != nil {
+				t.Errorf("Error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if built.Load() != 1 {
+		t.Errorf("Expected a single initialization, got %v", built.Load())
+	}
+}
+
+func TestWarmHttpPool(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	pool := NewWarmHttpPool(func() (IHttpClient, error) {
+		return NewHttpClient(server.URL), nil
+	}, 3)
+
+	for i := 0; i < 5; i++ {
+		if _, err := pool.Get(); err != nil {
+			t.Errorf("Error: %v", err)
+		}
+	}
+
+	failing := NewWarmHttpPool(func() (IHttpClient, error) { return nil, errors.New("boom") }, 2)
+	if _, err := failing.Get(); err == nil {
+		t.Errorf("Expected error when no client could be built")
+	}
+}
+
+func TestWarmHttpPoolRecovers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	var calls atomic.Int32
+	pool := NewWarmHttpPool(func() (IHttpClient, error) {
+		if calls.Add(1) <= 2 {
+			return nil, errors.New("dns not ready")
+		}
+		return NewHttpClient(server.URL, WithDefaults(WithCache(newMapCache(), time.Minute, ""))), nil
+	}, 2)
+
+	// The first request may find every client failed and give up
+	sent := int64(0)
+	for i := 0; i < 5; i++ {
+		if _, err := pool.Get(); err == nil {
+			sent++
+		} else if i > 0 {
+			t.Errorf("Expected failed clients to be built again, got %v", err)
+		}
+	}
+	for deadline := time.Now().Add(time.Second); len(pool.(*delegateClient).clients()) < 2 && time.Now().Before(deadline); {
+		pool.Get()
+		sent++
+		time.Sleep(time.Millisecond)
+	}
+	if clients := len(pool.(*delegateClient).clients()); clients != 2 || calls.Load() != 4 {
+		t.Errorf("Expected both failed clients to be built again, got %d clients after %d factory calls", clients, calls.Load())
+	}
+	if stats := pool.CacheStats(); stats.Hits+stats.Misses != sent {
+		t.Errorf("Expected the cache stats of every client, got %+v for %d requests", stats, sent)
+	}
+}
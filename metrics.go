@@ -0,0 +1,65 @@
+package easyrqst
+
+import (
+	"net/http"
+	"time"
+)
+
+// MetricLabels identify the series a measurement belongs to.
+type MetricLabels struct {
+	Client string
+	Host   string
+	Method string
+}
+
+// IMetricsRecorder receives the measurements of a client. It must be safe for
+// concurrent use.
+type IMetricsRecorder interface {
+	// ObserveRequest is called for every request that went to the network, once its
+	// retries are done. statusCode is 0 when err is set.
+	ObserveRequest(labels MetricLabels, statusCode int, elapsed time.Duration, err error)
+	ObserveRetry(labels MetricLabels)
+	ObserveCache(labels MetricLabels, hit bool)
+	// AddInFlight moves the number of requests on the network by delta.
+	AddInFlight(labels MetricLabels, delta int)
+}
+
+type metricsObj struct {
+	recorder IMetricsRecorder
+	client   string
+}
+
+// WithMetrics reports the requests of the client to recorder, labeled with name.
+func WithMetrics(recorder IMetricsRecorder, name string) THttpOption {
+	return func(o *easyRequest) { o.metrics = &metricsObj{recorder: recorder, client: name} }
+}
+
+func (m *metricsObj) labels(req *http.Request) MetricLabels {
+	return MetricLabels{Client: m.client, Host: req.URL.Host, Method: req.Method}
+}
+
+// observe counts req in flight; the returned function records its outcome.
+func (m *metricsObj) observe(req *http.Request) func(statusCode int, err error) {
+	if m == nil {
+		return func(int, error) {}
+	}
+	labels := m.labels(req)
+	start := time.Now()
+	m.recorder.AddInFlight(labels, 1)
+	return func(statusCode int, err error) {
+		m.recorder.AddInFlight(labels, -1)
+		m.recorder.ObserveRequest(labels, statusCode, time.Since(start), err)
+	}
+}
+
+func (m *metricsObj) retry(req *http.Request) {
+	if m != nil && req != nil {
+		m.recorder.ObserveRetry(m.labels(req))
+	}
+}
+
+func (m *metricsObj) cache(req *http.Request, hit bool) {
+	if m != nil {
+		m.recorder.ObserveCache(m.labels(req), hit)
+	}
+}
@@ -0,0 +1,174 @@
+package easyrqst
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of the latency histogram.
+var DefaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// PrometheusMetrics records client metrics and serves them in the Prometheus text
+// exposition format, without depending on the Prometheus client library. Mount it on
+// the /metrics route of the service.
+type PrometheusMetrics struct {
+	namespace string
+	buckets   []float64
+	mu        sync.Mutex
+	requests  map[string]uint64
+	latencies map[string]*histogram
+	retries   map[string]uint64
+	cache     map[string]uint64
+	inFlight  map[string]int
+}
+
+// NewPrometheusMetrics prefixes every metric with namespace, e.g. "easyrqst". Latencies
+// use DefaultLatencyBuckets when buckets is empty.
+func NewPrometheusMetrics(namespace string, buckets ...float64) *PrometheusMetrics {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	buckets = append([]float64{}, buckets...)
+	sort.Float64s(buckets)
+	return &PrometheusMetrics{
+		namespace: namespace,
+		buckets:   buckets,
+		requests:  make(map[string]uint64),
+		latencies: make(map[string]*histogram),
+		retries:   make(map[string]uint64),
+		cache:     make(map[string]uint64),
+		inFlight:  make(map[string]int),
+	}
+}
+
+func promLabels(labels MetricLabels, extra ...string) string {
+	pairs := append([]string{"client", labels.Client, "host", labels.Host, "method", labels.Method}, extra...)
+	var b strings.Builder
+	for i := 0; i < len(pairs); i += 2 {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		value := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(pairs[i+1])
+		fmt.Fprintf(&b, `%s="%s"`, pairs[i], value)
+	}
+	return b.String()
+}
+
+func (p *PrometheusMetrics) ObserveRequest(labels MetricLabels, statusCode int, elapsed time.Duration, err error) {
+	code := strconv.Itoa(statusCode)
+	if err != nil {
+		code = "error"
+	}
+	series := promLabels(labels)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.requests[promLabels(labels, "code", code)]++
+	h, ok := p.latencies[series]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(p.buckets))}
+		p.latencies[series] = h
+	}
+	seconds := elapsed.Seconds()
+	for i, bound := range p.buckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+func (p *PrometheusMetrics) ObserveRetry(labels MetricLabels) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.retries[promLabels(labels)]++
+}
+
+func (p *PrometheusMetrics) ObserveCache(labels MetricLabels, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cache[promLabels(labels, "result", result)]++
+}
+
+func (p *PrometheusMetrics) AddInFlight(labels MetricLabels, delta int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.inFlight[promLabels(labels)] += delta
+}
+
+func (p *PrometheusMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	p.WriteTo(w)
+}
+
+// WriteTo writes the current metrics in the Prometheus text exposition format.
+func (p *PrometheusMetrics) WriteTo(w io.Writer) (int64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var b strings.Builder
+	name := func(metric string) string {
+		if p.namespace == "" {
+			return metric
+		}
+		return p.namespace + "_" + metric
+	}
+	writeCounters := func(metric, help string, series map[string]uint64) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s counter\n", name(metric), help, name(metric))
+		for _, labels := range sortedKeys(series) {
+			fmt.Fprintf(&b, "%s{%s} %d\n", name(metric), labels, series[labels])
+		}
+	}
+
+	writeCounters("requests_total", "Requests sent, by status code.", p.requests)
+
+	metric := name("request_duration_seconds")
+	fmt.Fprintf(&b, "# HELP %s Request latency, retries included.\n# TYPE %s histogram\n", metric, metric)
+	for _, labels := range sortedKeys(p.latencies) {
+		h := p.latencies[labels]
+		for i, bound := range p.buckets {
+			fmt.Fprintf(&b, "%s_bucket{%s,le=\"%s\"} %d\n", metric, labels, strconv.FormatFloat(bound, 'g', -1, 64), h.counts[i])
+		}
+		fmt.Fprintf(&b, "%s_bucket{%s,le=\"+Inf\"} %d\n", metric, labels, h.count)
+		fmt.Fprintf(&b, "%s_sum{%s} %s\n", metric, labels, strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(&b, "%s_count{%s} %d\n", metric, labels, h.count)
+	}
+
+	writeCounters("retries_total", "Retried attempts.", p.retries)
+	writeCounters("cache_requests_total", "Cache lookups, by result.", p.cache)
+
+	metric = name("in_flight_requests")
+	fmt.Fprintf(&b, "# HELP %s Requests on the network.\n# TYPE %s gauge\n", metric, metric)
+	for _, labels := range sortedKeys(p.inFlight) {
+		fmt.Fprintf(&b, "%s{%s} %d\n", metric, labels, p.inFlight[labels])
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
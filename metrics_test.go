@@ -0,0 +1,56 @@
+package easyrqst
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrometheusMetrics(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	metrics := NewPrometheusMetrics("easyrqst", 0.5, 1)
+	call := NewHttpClient(server.URL, WithRetryWaitMax(time.Millisecond), WithMetrics(metrics, "users"))
+	cache := WithCache(newMapCache(), time.Minute, "v1")
+	for i := 0; i < 2; i++ {
+		if _, err := call.Get(cache); err != nil {
+			t.Errorf("Error: %v", err)
+			return
+		}
+	}
+
+	exporter := httptest.NewServer(metrics)
+	defer exporter.Close()
+	resp, err := http.Get(exporter.URL)
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	labels := `client="users",host="` + strings.TrimPrefix(server.URL, "http://") + `",method="GET"`
+	for _, line := range []string{
+		`easyrqst_requests_total{` + labels + `,code="200"} 1`,
+		`easyrqst_request_duration_seconds_bucket{` + labels + `,le="+Inf"} 1`,
+		`easyrqst_request_duration_seconds_count{` + labels + `} 1`,
+		`easyrqst_retries_total{` + labels + `} 1`,
+		`easyrqst_cache_requests_total{` + labels + `,result="hit"} 1`,
+		`easyrqst_cache_requests_total{` + labels + `,result="miss"} 1`,
+		`easyrqst_in_flight_requests{` + labels + `} 0`,
+	} {
+		if !strings.Contains(string(body), line+"\n") {
+			t.Errorf("Expected %s in\n%s", line, body)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package easyrqst
+
+import "net/http"
+
+// THandler executes a prepared request: cache lookup, retries and response handling.
+type THandler func(req *http.Request) (*HttpResponse, error)
+
+// TMiddleware wraps a handler to add behavior around it.
+type TMiddleware func(next THandler) THandler
+
+// WithMiddleware wraps every request of the client, the first middleware being the
+// outermost. Requests reach the middlewares prepared and signed, cache hits included.
+func WithMiddleware(middlewares ...TMiddleware) THttpOption {
+	return func(o *easyRequest) { o.middlewares = append(o.middlewares, middlewares...) }
+}
+
+func (h *easyRequest) handle(req *http.Request, options *ReqOptions) (*HttpResponse, error) {
+	handler := func(req *http.Request) (*HttpResponse, error) {
+		return h.executeRequest(req, options)
+	}
+	for i := len(h.middlewares) - 1; i >= 0; i-- {
+		handler = h.middlewares[i](handler)
+	}
+	return handler(req)
+}
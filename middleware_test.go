@@ -0,0 +1,48 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get("X-Trace")))
+	}))
+	defer server.Close()
+
+	var order []string
+	trace := func(name string) TMiddleware {
+		return func(next THandler) THandler {
+			return func(req *http.Request) (*HttpResponse, error) {
+				order = append(order, name)
+				req.Header.Add("X-Trace", name)
+				return next(req)
+			}
+		}
+	}
+	upper := func(next THandler) THandler {
+		return func(req *http.Request) (*HttpResponse, error) {
+			response, err := next(req)
+			if err == nil {
+				response.Body = []byte(strings.ToUpper(string(response.Body)))
+			}
+			return response, err
+		}
+	}
+
+	call := NewHttpClient(server.URL, WithMiddleware(trace("outer"), trace("inner")), WithMiddleware(upper))
+	outcome, err := call.Get()
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if strings.Join(order, ",") != "outer,inner" {
+		t.Errorf("Expected outer middleware first, got %v", order)
+	}
+	if string(outcome.Body) != "OUTER" {
+		t.Errorf("Expected rewritten response with headers from middlewares, got %s", outcome.Body)
+	}
+}
@@ -0,0 +1,296 @@
+package easyrqst
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// MsgpackCodec stores responses as MessagePack maps, compact and readable from other
+// languages sharing the cache.
+type MsgpackCodec struct{}
+
+func (MsgpackCodec) Encode(response *HttpResponse) ([]byte, error) {
+	entry := toCached(response)
+	var w msgpackWriter
+	w.mapHeader(11)
+	w.str("version")
+	w.int(int64(entry.Version))
+	w.str("method")
+	w.str(entry.Method)
+	w.str("status")
+	w.int(int64(entry.StatusCode))
+	w.str("header")
+	names := make([]string, 0, len(entry.Header))
+	for name := range entry.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	w.mapHeader(len(names))
+	for _, name := range names {
+		w.str(name)
+		w.arrayHeader(len(entry.Header[name]))
+		for _, value := range entry.Header[name] {
+			w.str(value)
+		}
+	}
+	w.str("body")
+	w.bin(entry.Body)
+	w.str("cached_at")
+	w.int(entry.CachedAt.UnixNano())
+	w.str("max_age")
+	w.int(int64(entry.MaxAge))
+	w.str("varied")
+	varied := make([]string, 0, len(entry.Varied))
+	for name := range entry.Varied {
+		varied = append(varied, name)
+	}
+	sort.Strings(varied)
+	w.mapHeader(len(varied))
+	for _, name := range varied {
+		w.str(name)
+		w.str(entry.Varied[name])
+	}
+	w.str("api_version")
+	w.str(entry.APIVersion)
+	w.str("archive_entry")
+	w.str(entry.ArchiveEntry)
+	w.str("final_url")
+	w.str(entry.FinalURL)
+	return w.buf, nil
+}
+
+func (MsgpackCodec) Decode(data []byte) (*HttpResponse, error) {
+	r := msgpackReader{buf: data}
+	value, err := r.value()
+	if err != nil {
+		return nil, err
+	}
+	fields, ok := value.(map[string]any)
+	if !ok {
+		return nil, errors.New("msgpack cache entry is not a map")
+	}
+
+	entry := cachedResponse{Header: http.Header{}}
+	entry.Version = int(msgpackInt(fields["version"]))
+	entry.Method, _ = fields["method"].(string)
+	entry.StatusCode = int(msgpackInt(fields["status"]))
+	if header, ok := fields["header"].(map[string]any); ok {
+		for name, values := range header {
+			list, _ := values.([]any)
+			for _, value := range list {
+				if s, ok := value.(string); ok {
+					entry.Header[name] = append(entry.Header[name], s)
+				}
+			}
+		}
+	}
+	entry.Body, _ = fields["body"].([]byte)
+	if nanos := msgpackInt(fields["cached_at"]); nanos != 0 {
+		entry.CachedAt = time.Unix(0, nanos)
+	}
+	entry.MaxAge = time.Duration(msgpackInt(fields["max_age"]))
+	if varied, ok := fields["varied"].(map[string]any); ok && len(varied) > 0 {
+		entry.Varied = make(map[string]string, len(varied))
+		for name, value := range varied {
+			entry.Varied[name], _ = value.(string)
+		}
+	}
+	entry.APIVersion, _ = fields["api_version"].(string)
+	entry.ArchiveEntry, _ = fields["archive_entry"].(string)
+	entry.FinalURL, _ = fields["final_url"].(string)
+	return fromCached(entry)
+}
+
+func msgpackInt(v any) int64 {
+	switch n := v.(type) {
+	case int64:
+		return n
+	case uint64:
+		if n <= math.MaxInt64 {
+			return int64(n)
+		}
+	}
+	return 0
+}
+
+type msgpackWriter struct {
+	buf []byte
+}
+
+func (w *msgpackWriter) sized(fix, max byte, b8, b16, b32 byte, n int) {
+	switch {
+	case fix != 0 && n < int(max):
+		w.buf = append(w.buf, fix|byte(n))
+	case b8 != 0 && n <= math.MaxUint8:
+		w.buf = append(w.buf, b8, byte(n))
+	case n <= math.MaxUint16:
+		w.buf = binary.BigEndian.AppendUint16(append(w.buf, b16), uint16(n))
+	default:
+		w.buf = binary.BigEndian.AppendUint32(append(w.buf, b32), uint32(n))
+	}
+}
+
+func (w *msgpackWriter) mapHeader(n int) {
+	w.sized(0x80, 16, 0, 0xde, 0xdf, n)
+}
+
+func (w *msgpackWriter) arrayHeader(n int) {
+	w.sized(0x90, 16, 0, 0xdc, 0xdd, n)
+}
+
+func (w *msgpackWriter) str(s string) {
+	w.sized(0xa0, 32, 0xd9, 0xda, 0xdb, len(s))
+	w.buf = append(w.buf, s...)
+}
+
+func (w *msgpackWriter) bin(b []byte) {
+	w.sized(0, 0, 0xc4, 0xc5, 0xc6, len(b))
+	w.buf = append(w.buf, b...)
+}
+
+func (w *msgpackWriter) int(n int64) {
+	if n >= 0 && n < 128 {
+		w.buf = append(w.buf, byte(n))
+		return
+	}
+	w.buf = binary.BigEndian.AppendUint64(append(w.buf, 0xd3), uint64(n))
+}
+
+// msgpackReader decodes the subset of MessagePack written by msgpackWriter, plus the
+// other integer and nil encodings other implementations may pick.
+type msgpackReader struct {
+	buf []byte
+	pos int
+}
+
+var errMsgpackShort = errors.New("truncated msgpack cache entry")
+
+func (r *msgpackReader) next(n int) ([]byte, error) {
+	if n < 0 || r.pos+n > len(r.buf) {
+		return nil, errMsgpackShort
+	}
+	b := r.buf[r.pos : r.pos+n]
+	r.pos += n
+	return b, nil
+}
+
+func (r *msgpackReader) length(size int) (int, error) {
+	b, err := r.next(size)
+	if err != nil {
+		return 0, err
+	}
+	switch size {
+	case 1:
+		return int(b[0]), nil
+	case 2:
+		return int(binary.BigEndian.Uint16(b)), nil
+	}
+	return int(binary.BigEndian.Uint32(b)), nil
+}
+
+func (r *msgpackReader) value() (any, error) {
+	b, err := r.next(1)
+	if err != nil {
+		return nil, err
+	}
+	tag := b[0]
+	switch {
+	case tag <= 0x7f:
+		return int64(tag), nil
+	case tag >= 0xe0:
+		return int64(int8(tag)), nil
+	case tag&0xf0 == 0x80:
+		return r.mapOf(int(tag & 0x0f))
+	case tag&0xf0 == 0x90:
+		return r.arrayOf(int(tag & 0x0f))
+	case tag&0xe0 == 0xa0:
+		return r.strOf(int(tag & 0x1f))
+	}
+
+	sizes := map[byte]int{0xc4: 1, 0xc5: 2, 0xc6: 4, 0xd9: 1, 0xda: 2, 0xdb: 4, 0xdc: 2, 0xdd: 4, 0xde: 2, 0xdf: 4}
+	if size, ok := sizes[tag]; ok {
+		n, err := r.length(size)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case tag <= 0xc6:
+			data, err := r.next(n)
+			return append([]byte{}, data...), err
+		case tag <= 0xdb:
+			return r.strOf(n)
+		case tag <= 0xdd:
+			return r.arrayOf(n)
+		}
+		return r.mapOf(n)
+	}
+
+	switch tag {
+	case 0xc0:
+		return nil, nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		data, err := r.next(1 << (tag - 0xcc))
+		if err != nil {
+			return nil, err
+		}
+		var n uint64
+		for _, c := range data {
+			n = n<<8 | uint64(c)
+		}
+		return n, nil
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (tag - 0xd0)
+		data, err := r.next(size)
+		if err != nil {
+			return nil, err
+		}
+		var n uint64
+		for _, c := range data {
+			n = n<<8 | uint64(c)
+		}
+		// Sign extend from the encoded width
+		shift := 64 - 8*size
+		return int64(n<<shift) >> shift, nil
+	}
+	return nil, fmt.Errorf("unsupported msgpack type 0x%02x", tag)
+}
+
+func (r *msgpackReader) strOf(n int) (any, error) {
+	data, err := r.next(n)
+	return string(data), err
+}
+
+func (r *msgpackReader) arrayOf(n int) (any, error) {
+	list := make([]any, 0, min(n, len(r.buf)))
+	for i := 0; i < n; i++ {
+		value, err := r.value()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, value)
+	}
+	return list, nil
+}
+
+func (r *msgpackReader) mapOf(n int) (any, error) {
+	fields := make(map[string]any, min(n, len(r.buf)))
+	for i := 0; i < n; i++ {
+		key, err := r.value()
+		if err != nil {
+			return nil, err
+		}
+		name, ok := key.(string)
+		if !ok {
+			return nil, errors.New("msgpack map key is not a string")
+		}
+		if fields[name], err = r.value(); err != nil {
+			return nil, err
+		}
+	}
+	return fields, nil
+}
@@ -0,0 +1,36 @@
+package easyrqst
+
+import (
+	"net/http"
+	"time"
+)
+
+type negativeCaching struct {
+	ttl      time.Duration
+	statuses map[int]bool
+}
+
+// WithNegativeCaching also caches 404 and 410 responses, or those with the given
+// statuses, for ttl, so lookups of missing resources don't all reach the origin.
+func WithNegativeCaching(ttl time.Duration, statuses ...int) THttpOption {
+	return func(o *easyRequest) {
+		if len(statuses) == 0 {
+			statuses = []int{http.StatusNotFound, http.StatusGone}
+		}
+		o.negative = &negativeCaching{ttl: ttl, statuses: make(map[int]bool)}
+		for _, status := range statuses {
+			o.negative.statuses[status] = true
+		}
+	}
+}
+
+// cacheTTL is how long responses with status are cached, and whether they are at all.
+func (h *easyRequest) cacheTTL(status int, expiry time.Duration) (time.Duration, bool) {
+	if (h.cacheable == nil && (status == http.StatusOK || status == http.StatusCreated)) || h.cacheable[status] {
+		return expiry, true
+	}
+	if h.negative != nil && h.negative.statuses[status] {
+		return h.negative.ttl, true
+	}
+	return 0, false
+}
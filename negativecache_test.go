@@ -0,0 +1,59 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNegativeCaching(t *testing.T) {
+	calls := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/broken":
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	cache := &expiryCache{mapCache: newMapCache(), expiries: make(map[string]time.Duration)}
+	call := NewHttpClient(server.URL, WithNegativeCaching(20*time.Millisecond), WithDefaults(WithCache(cache, time.Minute, "")))
+	for i := 0; i < 2; i++ {
+		call.Get(WithPath("missing"))
+		call.Get(WithPath("broken"))
+		call.Get(WithPath("found"))
+	}
+	if calls["/missing"] != 1 || calls["/broken"] != 2 || calls["/found"] != 1 {
+		t.Errorf("Expected 404 and 200 responses cached, got %v", calls)
+	}
+	if cache.expiries["GET__/missing?"] != 20*time.Millisecond || cache.expiries["GET__/found?"] != time.Minute {
+		t.Errorf("Expected the negative TTL for the 404, got %v", cache.expiries)
+	}
+
+	resp, err := call.Get(WithPath("missing"))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if !resp.FromCache || resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected the cached 404, got %v %d", resp.FromCache, resp.StatusCode)
+	}
+}
+
+func TestNegativeCachingStatuses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}))
+	defer server.Close()
+
+	cache := newMapCache()
+	call := NewHttpClient(server.URL, WithNegativeCaching(time.Minute, http.StatusUnprocessableEntity), WithDefaults(WithCache(cache, time.Minute, "")))
+	call.Get()
+	if resp, _ := call.Get(); !resp.FromCache {
+		t.Errorf("Expected the configured status to be cached")
+	}
+}
@@ -0,0 +1,68 @@
+package easyrqst
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"syscall"
+)
+
+// Transport failures are returned wrapped in a *TransportError, so they can be told
+// apart with errors.Is while the original error stays reachable with errors.As.
+var (
+	ErrTimeout           = errors.New("request timed out")
+	ErrDNS               = errors.New("dns lookup failed")
+	ErrTLSHandshake      = errors.New("tls handshake failed")
+	ErrConnectionRefused = errors.New("connection refused")
+	ErrTooManyRedirects  = errors.New("too many redirects")
+)
+
+// TransportError is a network failure classified as one of the Err sentinels above.
+type TransportError struct {
+	Kind error
+	err  error
+}
+
+func (e *TransportError) Error() string {
+	return e.err.Error()
+}
+
+func (e *TransportError) Unwrap() []error {
+	return []error{e.Kind, e.err}
+}
+
+func classifyTransportError(err error) error {
+	if kind := transportErrorKind(err); kind != nil {
+		return &TransportError{Kind: kind, err: err}
+	}
+	return err
+}
+
+func transportErrorKind(err error) error {
+	var dnsErr *net.DNSError
+	var verifyErr *tls.CertificateVerificationError
+	var recordErr tls.RecordHeaderError
+	var alertErr tls.AlertError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	var netErr net.Error
+	switch {
+	case errors.Is(err, ErrTooManyRedirects):
+		// Already says what it is, the redirect check wraps the sentinel itself
+		return nil
+	case errors.As(err, &dnsErr):
+		return ErrDNS
+	case errors.As(err, &verifyErr), errors.As(err, &recordErr), errors.As(err, &alertErr),
+		errors.As(err, &authorityErr), errors.As(err, &hostnameErr), errors.As(err, &invalidErr),
+		errors.Is(err, ErrPinMismatch):
+		return ErrTLSHandshake
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return ErrConnectionRefused
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return ErrTimeout
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package easyrqst
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTransportErrors(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer slow.Close()
+	loop := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/again", http.StatusFound)
+	}))
+	defer loop.Close()
+	secure := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer secure.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed := "http://" + listener.Addr().String()
+	listener.Close()
+
+	tests := []struct {
+		name     string
+		endpoint string
+		opts     []THttpOption
+		expected error
+	}{
+		{"timeout", slow.URL, []THttpOption{WithClientTimeout(50 * time.Millisecond)}, ErrTimeout},
+		{"redirects", loop.URL, nil, ErrTooManyRedirects},
+		{"tls", secure.URL, nil, ErrTLSHandshake},
+		{"refused", closed, nil, ErrConnectionRefused},
+		{"dns", "http://easyrqst.invalid", nil, ErrDNS},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewHttpClient(tt.endpoint, append(tt.opts, WithRetry(0))...).Get()
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+
+	_, err = NewHttpClient(closed, WithRetry(0)).Get()
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) {
+		t.Errorf("Expected the original error to stay reachable, got %v", err)
+	}
+	if errors.Is(err, ErrTimeout) {
+		t.Errorf("Expected a refused connection not to be a timeout, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package easyrqst
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+type ITokenSource interface {
+	Token(ctx context.Context) (string, error)
+	Invalidate()
+}
+
+type ClientCredentialsConfig struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	// EndpointParams are extra form values sent to the token endpoint, e.g. audience.
+	EndpointParams map[string]string
+	// AuthInBody sends the client id and secret as form values instead of basic auth.
+	AuthInBody bool
+	// ExpiryDelta refreshes the token this long before it actually expires. Defaults to 10s.
+	ExpiryDelta time.Duration
+}
+
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+type clientCredentials struct {
+	mu     sync.Mutex
+	cfg    ClientCredentialsConfig
+	client IHttpClient
+	token  string
+	expiry time.Time
+}
+
+// NewClientCredentials returns a token source performing the OAuth2 client-credentials
+// flow. Tokens are cached until they are about to expire; opts configure the client
+// used to talk to the token endpoint.
+func NewClientCredentials(cfg ClientCredentialsConfig, opts ...THttpOption) ITokenSource {
+	if cfg.ExpiryDelta == 0 {
+		cfg.ExpiryDelta = 10 * time.Second
+	}
+	return &clientCredentials{cfg: cfg, client: NewHttpClient(cfg.TokenURL, opts...)}
+}
+
+// WithTokenSource authenticates every request with a bearer token from the source.
+func WithTokenSource(source ITokenSource) THttpOption {
+	return WithTokenProvider(source.Token)
+}
+
+func (c *clientCredentials) Token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" && (c.expiry.IsZero() || time.Now().Before(c.expiry)) {
+		return c.token, nil
+	}
+
+	token, expiry, err := c.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.token, c.expiry = token, expiry
+	return token, nil
+}
+
+func (c *clientCredentials) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token, c.expiry = "", time.Time{}
+}
+
+func (c *clientCredentials) fetch(ctx context.Context) (string, time.Time, error) {
+	form := map[string]string{"grant_type": "client_credentials"}
+	if len(c.cfg.Scopes) > 0 {
+		form["scope"] = strings.Join(c.cfg.Scopes, " ")
+	}
+	for k, v := range c.cfg.EndpointParams {
+		form[k] = v
+	}
+
+	opts := []TReqOption{
+		WithContext(ctx),
+		WithMergedHeaders(map[string]string{"Content-Type": "application/x-www-form-urlencoded", "Accept": "application/json"}),
+	}
+	if c.cfg.AuthInBody {
+		form["client_id"] = c.cfg.ClientID
+		form["client_secret"] = c.cfg.ClientSecret
+	} else {
+		opts = append(opts, WithBasicAuth(url.QueryEscape(c.cfg.ClientID), url.QueryEscape(c.cfg.ClientSecret)))
+	}
+	opts = append(opts, WithPayload(form))
+
+	outcome, err := c.client.Post(opts...)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to fetch oauth2 token: %w", err)
+	}
+
+	var token tokenResponse
+	if err := json.Unmarshal(outcome.Body, &token); err != nil && outcome.StatusCode == http.StatusOK {
+		return "", time.Time{}, fmt.Errorf("failed to decode oauth2 token: %v", err)
+	}
+	if outcome.StatusCode != http.StatusOK || token.AccessToken == "" {
+		if token.Error != "" {
+			return "", time.Time{}, fmt.Errorf("oauth2 token endpoint returned %d: %s %s", outcome.StatusCode, token.Error, token.ErrorDescription)
+		}
+		return "", time.Time{}, fmt.Errorf("oauth2 token endpoint returned %d without an access token", outcome.StatusCode)
+	}
+
+	var expiry time.Time
+	if token.ExpiresIn > 0 {
+		expiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - c.cfg.ExpiryDelta)
+	}
+	return token.AccessToken, expiry, nil
+}
@@ -0,0 +1,56 @@
+package easyrqst
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientCredentials(t *testing.T) {
+	issued := 0
+	tokens := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		if user != "client" || pass != "secret" || r.FormValue("grant_type") != "client_credentials" || r.FormValue("scope") != "read write" {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+			return
+		}
+		issued++
+		json.NewEncoder(w).Encode(map[string]any{"access_token": fmt.Sprintf("token-%d", issued), "token_type": "Bearer", "expires_in": 3600})
+	}))
+	defer tokens.Close()
+
+	api := newEchoAuthServer()
+	defer api.Close()
+
+	source := NewClientCredentials(ClientCredentialsConfig{TokenURL: tokens.URL, ClientID: "client", ClientSecret: "secret", Scopes: []string{"read", "write"}})
+	call := NewHttpClient(api.URL, WithTokenSource(source))
+
+	for _, expected := range []string{"Bearer token-1", "Bearer token-1"} {
+		outcome, err := call.Get()
+		if err != nil {
+			t.Errorf("Error: %v", err)
+			return
+		}
+		if string(outcome.Body) != expected {
+			t.Errorf("Expected %s, got %s", expected, outcome.Body)
+		}
+	}
+
+	source.Invalidate()
+	outcome, err := call.Get()
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if string(outcome.Body) != "Bearer token-2" {
+		t.Errorf("Expected refreshed token, got %s", outcome.Body)
+	}
+
+	bad := NewClientCredentials(ClientCredentialsConfig{TokenURL: tokens.URL, ClientID: "client", ClientSecret: "wrong"})
+	if _, err := NewHttpClient(api.URL, WithTokenSource(bad)).Get(); err == nil {
+		t.Errorf("Expected token endpoint error")
+	}
+}
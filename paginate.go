@@ -0,0 +1,254 @@
+package easyrqst
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var ErrNoMorePages = errors.New("no more pages")
+
+// TPageStrategy finds the page following response. It returns the request options
+// selecting it, or nil when response is the last page.
+type TPageStrategy func(response *HttpResponse) ([]TReqOption, error)
+
+// Pages iterates over a paginated resource.
+type Pages struct {
+	client   IHttpClient
+	strategy TPageStrategy
+	opts     []TReqOption
+	next     []TReqOption
+	done     bool
+}
+
+// Paginate returns an iterator over the pages of the resource requested by opts. Pages
+// are fetched with GET through client, with its retry, cache and auth configuration.
+func Paginate(client IHttpClient, strategy TPageStrategy, opts ...TReqOption) *Pages {
+	return &Pages{client: client, strategy: strategy, opts: opts}
+}
+
+// More reports whether Next has a page left to fetch.
+func (p *Pages) More() bool {
+	return !p.done
+}
+
+// Next fetches the next page. Once the last page was returned it fails with
+// ErrNoMorePages. Any status other than 2xx is returned as an error.
+func (p *Pages) Next(ctx context.Context) (*HttpResponse, error) {
+	if p.done {
+		return nil, ErrNoMorePages
+	}
+	opts := append(append(p.opts[:len(p.opts):len(p.opts)], p.next...), WithContext(ctx))
+	response, err := p.client.Get(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response, fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+	next, err := p.strategy(response)
+	if err != nil {
+		return response, err
+	}
+	p.next, p.done = next, next == nil
+	return response, nil
+}
+
+func withLocation(location string) TReqOption {
+	return func(o *ReqOptions) { o.location = location }
+}
+
+// LinkHeaderPages follows the rel="next" URL of RFC 5988 Link headers, as sent by e.g.
+// GitHub. Query parameters of the request only apply if the URL doesn't set them.
+func LinkHeaderPages() TPageStrategy {
+	return func(response *HttpResponse) ([]TReqOption, error) {
+		next := nextLink(response.Header.Values("Link"))
+		if next == "" {
+			return nil, nil
+		}
+		if response.url != nil {
+			u, err := response.url.Parse(next)
+			if err != nil {
+				return nil, fmt.Errorf("invalid next link %q: %w", next, err)
+			}
+			next = u.String()
+		}
+		return []TReqOption{withLocation(next)}, nil
+	}
+}
+
+func nextLink(headers []string) string {
+	for _, header := range headers {
+		for _, link := range strings.Split(header, ",") {
+			target, params, ok := strings.Cut(strings.TrimSpace(link), ";")
+			if !ok || !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range strings.Split(params, ";") {
+				name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if !strings.EqualFold(name, "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(value, `"`)) {
+					if strings.EqualFold(rel, "next") {
+						return target[1 : len(target)-1]
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// CursorPages reads the cursor of the next page from the dot separated cursorPath of
+// the JSON body and sends it as the param query parameter. A missing, null or empty
+// cursor ends the pagination.
+func CursorPages(cursorPath, param string) TPageStrategy {
+	return func(response *HttpResponse) ([]TReqOption, error) {
+		root, err := decodeJSONTree(response.Body)
+		if err != nil {
+			return nil, err
+		}
+		node, _ := jsonPath(root, cursorPath)
+		var cursor string
+		switch v := node.(type) {
+		case string:
+			cursor = v
+		case json.Number:
+			cursor = v.String()
+		case nil:
+		default:
+			return nil, fmt.Errorf("cursor %q is not a string or number", cursorPath)
+		}
+		if cursor == "" {
+			return nil, nil
+		}
+		return []TReqOption{WithMergedQueries(map[string]string{param: cursor})}, nil
+	}
+}
+
+// PageNumberPages counts pages in the param query parameter, starting from first. The
+// pagination ends at the first page whose itemsPath array is empty.
+func PageNumberPages(param string, first int, itemsPath string) TPageStrategy {
+	return func(response *HttpResponse) ([]TReqOption, error) {
+		items, err := pageItems(response, itemsPath)
+		if err != nil || items == 0 {
+			return nil, err
+		}
+		page, err := queryInt(response, param, first)
+		if err != nil {
+			return nil, err
+		}
+		return []TReqOption{WithMergedQueries(map[string]string{param: strconv.Itoa(page + 1)})}, nil
+	}
+}
+
+// OffsetPages moves the param query parameter forward by the number of items in the
+// itemsPath array of every page, starting from 0. The pagination ends at the first
+// empty page.
+func OffsetPages(param string, itemsPath string) TPageStrategy {
+	return func(response *HttpResponse) ([]TReqOption, error) {
+		items, err := pageItems(response, itemsPath)
+		if err != nil || items == 0 {
+			return nil, err
+		}
+		offset, err := queryInt(response, param, 0)
+		if err != nil {
+			return nil, err
+		}
+		return []TReqOption{WithMergedQueries(map[string]string{param: strconv.Itoa(offset + items)})}, nil
+	}
+}
+
+func decodeJSONTree(body []byte) (any, error) {
+	var root any
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&root); err != nil {
+		return nil, fmt.Errorf("failed to decode page: %w", err)
+	}
+	return root, nil
+}
+
+func pageItems(response *HttpResponse, itemsPath string) (int, error) {
+	root, err := decodeJSONTree(response.Body)
+	if err != nil {
+		return 0, err
+	}
+	node, _ := jsonPath(root, itemsPath)
+	items, ok := node.([]any)
+	if !ok && node != nil {
+		return 0, fmt.Errorf("page items %q are not an array", itemsPath)
+	}
+	return len(items), nil
+}
+
+// queryInt reads the integer param of the query the response was requested with.
+func queryInt(response *HttpResponse, param string, fallback int) (int, error) {
+	if response.url == nil || !response.url.Query().Has(param) {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(response.url.Query().Get(param))
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s query parameter: %w", param, err)
+	}
+	return n, nil
+}
+
+var ErrPaginationLimit = errors.New("pagination limit reached")
+
+// PageLimits bounds what PaginateAll collects. Zero means unlimited.
+type PageLimits struct {
+	MaxPages int
+	MaxItems int
+}
+
+// PaginateAll walks every page and decodes the itemsPath array of each into T; an
+// empty itemsPath decodes the whole body. When the resource goes past limits, the
+// items collected so far are returned along with ErrPaginationLimit.
+func PaginateAll[T any](ctx context.Context, client IHttpClient, strategy TPageStrategy, itemsPath string, limits PageLimits, opts ...TReqOption) ([]T, error) {
+	pages := Paginate(client, strategy, opts...)
+	var all []T
+	for count := 0; pages.More(); count++ {
+		if limits.MaxPages > 0 && count == limits.MaxPages {
+			return all, fmt.Errorf("%w: more than %d pages", ErrPaginationLimit, limits.MaxPages)
+		}
+		page, err := pages.Next(ctx)
+		if err != nil {
+			return all, err
+		}
+		items, err := decodeItems[T](page, itemsPath)
+		if err != nil {
+			return all, err
+		}
+		all = append(all, items...)
+		if limits.MaxItems > 0 && len(all) > limits.MaxItems {
+			return all[:limits.MaxItems], fmt.Errorf("%w: more than %d items", ErrPaginationLimit, limits.MaxItems)
+		}
+	}
+	return all, nil
+}
+
+func decodeItems[T any](page *HttpResponse, itemsPath string) ([]T, error) {
+	if itemsPath == "" {
+		return Decode[[]T](page)
+	}
+	root, err := decodeJSONTree(page.Body)
+	if err != nil {
+		return nil, err
+	}
+	node, ok := jsonPath(root, itemsPath)
+	if !ok {
+		return nil, fmt.Errorf("page has no %q node", itemsPath)
+	}
+	raw, err := json.Marshal(node)
+	if err != nil {
+		return nil, err
+	}
+	// Decoded on its own, the items aren't the cached body
+	return Decode[[]T](&HttpResponse{Header: page.Header, Body: raw, strict: page.strict})
+}
@@ -0,0 +1,118 @@
+package easyrqst
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func newPagedServer() *httptest.Server {
+	items := []string{"a", "b", "c", "d", "e"}
+	page := func(from int) string {
+		b, _ := json.Marshal(items[min(from, len(items)):min(from+2, len(items))])
+		return string(b)
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("api_key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/link":
+			n, _ := strconv.Atoi(query.Get("page"))
+			if n < 2 {
+				w.Header().Add("Link", fmt.Sprintf(`</link?page=%d>; rel="next", </link?page=0>; rel="first"`, n+1))
+			}
+			fmt.Fprintf(w, `{"items": %s}`, page(n*2))
+		case "/cursor":
+			from, _ := strconv.Atoi(query.Get("cursor"))
+			next := "null"
+			if from+2 < len(items) {
+				next = strconv.Quote(strconv.Itoa(from + 2))
+			}
+			fmt.Fprintf(w, `{"items": %s, "meta": {"next": %s}}`, page(from), next)
+		case "/pages":
+			n := 1
+			if query.Has("page") {
+				n, _ = strconv.Atoi(query.Get("page"))
+			}
+			fmt.Fprintf(w, `{"items": %s}`, page((n-1)*2))
+		case "/offset":
+			from, _ := strconv.Atoi(query.Get("offset"))
+			fmt.Fprintf(w, `{"items": %s}`, page(from))
+		}
+	}))
+}
+
+func TestPaginate(t *testing.T) {
+	server := newPagedServer()
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithDefaults(WithAPIKey("api_key", "secret", InQuery)))
+	for name, test := range map[string]struct {
+		path     string
+		strategy TPageStrategy
+		pages    int
+	}{
+		"link":   {"link", LinkHeaderPages(), 3},
+		"cursor": {"cursor", CursorPages("meta.next", "cursor"), 3},
+		"pages":  {"pages", PageNumberPages("page", 1, "items"), 4},
+		"offset": {"offset", OffsetPages("offset", "items"), 4},
+	} {
+		pages := Paginate(call, test.strategy, WithPath(test.path))
+		var collected []string
+		count := 0
+		for pages.More() {
+			page, err := pages.Next(context.Background())
+			if err != nil {
+				t.Errorf("%s: Error: %v", name, err)
+				break
+			}
+			body, err := Decode[struct{ Items []string }](page)
+			if err != nil {
+				t.Errorf("%s: Error: %v", name, err)
+				break
+			}
+			collected = append(collected, body.Items...)
+			count++
+		}
+		if fmt.Sprint(collected) != "[a b c d e]" || count != test.pages {
+			t.Errorf("%s: Expected all items in %d pages, got %v in %d", name, test.pages, collected, count)
+		}
+		if _, err := pages.Next(context.Background()); !errors.Is(err, ErrNoMorePages) {
+			t.Errorf("%s: Expected ErrNoMorePages, got %v", name, err)
+		}
+	}
+}
+
+func TestPaginateAll(t *testing.T) {
+	server := newPagedServer()
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithDefaults(WithAPIKey("api_key", "secret", InQuery)))
+	strategy := CursorPages("meta.next", "cursor")
+	items, err := PaginateAll[string](context.Background(), call, strategy, "items", PageLimits{}, WithPath("cursor"))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if fmt.Sprint(items) != "[a b c d e]" {
+		t.Errorf("Expected all items, got %v", items)
+	}
+
+	items, err = PaginateAll[string](context.Background(), call, strategy, "items", PageLimits{MaxPages: 2}, WithPath("cursor"))
+	if !errors.Is(err, ErrPaginationLimit) || len(items) != 4 {
+		t.Errorf("Expected page limit after 4 items, got %v %v", items, err)
+	}
+
+	items, err = PaginateAll[string](context.Background(), call, strategy, "items", PageLimits{MaxItems: 3}, WithPath("cursor"))
+	if !errors.Is(err, ErrPaginationLimit) || fmt.Sprint(items) != "[a b c]" {
+		t.Errorf("Expected item limit after 3 items, got %v %v", items, err)
+	}
+}
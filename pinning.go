@@ -0,0 +1,60 @@
+package easyrqst
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrPinMismatch = errors.New("server certificate does not match any pinned fingerprint")
+
+// WithPinnedCertificates only accepts servers whose leaf certificate or public key (SPKI)
+// SHA-256 hash matches one of the fingerprints. Fingerprints are hex, optionally colon
+// separated, or base64 with a "sha256/" prefix as used by HPKP.
+func WithPinnedCertificates(fingerprints ...string) THttpOption {
+	return func(o *easyRequest) {
+		pins := make([][]byte, 0, len(fingerprints))
+		for _, fingerprint := range fingerprints {
+			pin, err := decodePin(fingerprint)
+			if err != nil {
+				o.initErr = err
+				return
+			}
+			pins = append(pins, pin)
+		}
+
+		o.tlsConfig().VerifyConnection = func(cs tls.ConnectionState) error {
+			if len(cs.PeerCertificates) == 0 {
+				return ErrPinMismatch
+			}
+			leaf := cs.PeerCertificates[0]
+			certHash := sha256.Sum256(leaf.Raw)
+			spkiHash := sha256.Sum256(leaf.RawSubjectPublicKeyInfo)
+			for _, pin := range pins {
+				if bytes.Equal(pin, certHash[:]) || bytes.Equal(pin, spkiHash[:]) {
+					return nil
+				}
+			}
+			return ErrPinMismatch
+		}
+	}
+}
+
+func decodePin(fingerprint string) ([]byte, error) {
+	var pin []byte
+	var err error
+	if encoded, ok := strings.CutPrefix(fingerprint, "sha256/"); ok {
+		pin, err = base64.StdEncoding.DecodeString(encoded)
+	} else {
+		pin, err = hex.DecodeString(strings.ReplaceAll(fingerprint, ":", ""))
+	}
+	if err != nil || len(pin) != sha256.Size {
+		return nil, fmt.Errorf("invalid sha256 fingerprint %q", fingerprint)
+	}
+	return pin, nil
+}
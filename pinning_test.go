@@ -0,0 +1,38 @@
+package easyrqst
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPinnedCertificates(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	certHash := sha256.Sum256(server.Certificate().Raw)
+	spkiHash := sha256.Sum256(server.Certificate().RawSubjectPublicKeyInfo)
+	transport := server.Client().Transport.(*http.Transport)
+
+	for _, pin := range []string{strings.ToUpper(hex.EncodeToString(certHash[:])), "sha256/" + base64.StdEncoding.EncodeToString(spkiHash[:])} {
+		call := NewHttpClient(server.URL, WithTransport(transport.Clone()), WithPinnedCertificates(pin))
+		if _, err := call.Get(); err != nil {
+			t.Errorf("Error: %v", err)
+		}
+	}
+
+	wrong := sha256.Sum256([]byte("other"))
+	call := NewHttpClient(server.URL, WithTransport(transport.Clone()), WithPinnedCertificates(hex.EncodeToString(wrong[:])))
+	if _, err := call.Get(); !errors.Is(err, ErrPinMismatch) {
+		t.Errorf("Expected ErrPinMismatch, got %v", err)
+	}
+
+	if _, err := NewHttpClient(server.URL, WithPinnedCertificates("abc")).Get(); err == nil {
+		t.Errorf("Expected invalid fingerprint error")
+	}
+}
@@ -0,0 +1,149 @@
+package easyrqst
+
+import (
+	"errors"
+	"github.com/hashicorp/go-cleanhttp"
+	"sync"
+	"sync/atomic"
+)
+
+// EndpointState describes a pool endpoint to a balancer.
+type EndpointState struct {
+	Endpoint string
+	Pending  int
+}
+
+// IBalancer chooses the endpoint of every request made through a pool.
+type IBalancer interface {
+	// Pick returns the index of the endpoint to use. endpoints is never empty.
+	Pick(endpoints []EndpointState) int
+}
+
+type roundRobin struct {
+	next atomic.Uint64
+}
+
+func RoundRobin() IBalancer {
+	return &roundRobin{}
+}
+
+func (b *roundRobin) Pick(endpoints []EndpointState) int {
+	return int((b.next.Add(1) - 1) % uint64(len(endpoints)))
+}
+
+type weighted struct {
+	mu      sync.Mutex
+	weights map[string]int
+	current map[string]int
+}
+
+// Weighted spreads requests in proportion to the weight of each endpoint, interleaving
+// them smoothly. Endpoints without a weight count as 1.
+func Weighted(weights map[string]int) IBalancer {
+	return &weighted{weights: weights, current: make(map[string]int)}
+}
+
+func (b *weighted) Pick(endpoints []EndpointState) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	best, total := 0, 0
+	for i, e := range endpoints {
+		weight, ok := b.weights[e.Endpoint]
+		if !ok {
+			weight = 1
+		}
+		total += weight
+		b.current[e.Endpoint] += weight
+		if b.current[e.Endpoint] > b.current[endpoints[best].Endpoint] {
+			best = i
+		}
+	}
+	b.current[endpoints[best].Endpoint] -= total
+	return best
+}
+
+type leastPending struct {
+	next atomic.Uint64
+}
+
+// LeastPending sends requests to the endpoint with the fewest requests in flight, which
+// steers traffic away from slow replicas.
+func LeastPending() IBalancer {
+	return &leastPending{}
+}
+
+func (b *leastPending) Pick(endpoints []EndpointState) int {
+	// Start at a rotating offset so ties don't always go to the first endpoint
+	start := int(b.next.Add(1) % uint64(len(endpoints)))
+	best := start
+	for i := range endpoints {
+		j := (start + i) % len(endpoints)
+		if endpoints[j].Pending < endpoints[best].Pending {
+			best = j
+		}
+	}
+	return best
+}
+
+type poolMember struct {
+	endpoint string
+	client   IHttpClient
+	pending  atomic.Int64
+}
+
+type clientPool struct {
+	members  []*poolMember
+	balancer IBalancer
+}
+
+// NewHttpClientPool balances requests over replicas of a service. Every endpoint gets
+// its own client built with opts, sharing one connection pool.
+func NewHttpClientPool(endpoints []string, balancer IBalancer, opts ...THttpOption) IHttpClient {
+	if balancer == nil {
+		balancer = RoundRobin()
+	}
+	transport := cleanhttp.DefaultPooledTransport()
+	pool := &clientPool{balancer: balancer}
+	for _, endpoint := range endpoints {
+		memberOpts := append([]THttpOption{WithTransport(transport)}, opts...)
+		pool.members = append(pool.members, &poolMember{endpoint: endpoint, client: NewHttpClient(endpoint, memberOpts...)})
+	}
+	return &delegateClient{resolve: pool.resolve, clients: pool.clients}
+}
+
+func (p *clientPool) clients() []IHttpClient {
+	clients := make([]IHttpClient, len(p.members))
+	for i, m := range p.members {
+		clients[i] = m.client
+	}
+	return clients
+}
+
+func (p *clientPool) resolve() (IHttpClient, func(), error) {
+	if len(p.members) == 0 {
+		return nil, nil, errors.New("client pool has no endpoints")
+	}
+	members := p.healthy()
+	states := make([]EndpointState, len(members))
+	for i, m := range members {
+		states[i] = EndpointState{Endpoint: m.endpoint, Pending: int(m.pending.Load())}
+	}
+	member := members[p.balancer.Pick(states)]
+	member.pending.Add(1)
+	return member.client, func() { member.pending.Add(-1) }, nil
+}
+
+// healthy returns the members whose health checks pass, or all of them if none does.
+func (p *clientPool) healthy() []*poolMember {
+	var members []*poolMember
+	for _, m := range p.members {
+		if client, ok := m.client.(*easyRequest); !ok || client.healthy(m.endpoint) {
+			members = append(members, m)
+		}
+	}
+	if len(members) == 0 {
+		return p.members
+	}
+	return members
+}
@@ -0,0 +1,71 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newNamedServer(name string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("slow") != "" {
+			time.Sleep(50 * time.Millisecond)
+		}
+		w.Write([]byte(name))
+	}))
+}
+
+func poolCounts(t *testing.T, call IHttpClient, n int) map[string]int {
+	t.Helper()
+	counts := make(map[string]int)
+	for i := 0; i < n; i++ {
+		outcome, err := call.Get()
+		if err != nil {
+			t.Errorf("Error: %v", err)
+			return counts
+		}
+		counts[string(outcome.Body)]++
+	}
+	return counts
+}
+
+func TestPoolBalancers(t *testing.T) {
+	a, b := newNamedServer("a"), newNamedServer("b")
+	defer a.Close()
+	defer b.Close()
+
+	if counts := poolCounts(t, NewHttpClientPool([]string{a.URL, b.URL}, RoundRobin()), 10); counts["a"] != 5 || counts["b"] != 5 {
+		t.Errorf("Expected round robin to split evenly, got %v", counts)
+	}
+	if counts := poolCounts(t, NewHttpClientPool([]string{a.URL, b.URL}, Weighted(map[string]int{a.URL: 3})), 8); counts["a"] != 6 || counts["b"] != 2 {
+		t.Errorf("Expected 3:1 split, got %v", counts)
+	}
+	if _, err := NewHttpClientPool(nil, nil).Get(); err == nil {
+		t.Errorf("Expected error for an empty pool")
+	}
+}
+
+func TestPoolLeastPending(t *testing.T) {
+	a, b := newNamedServer("a"), newNamedServer("b")
+	defer a.Close()
+	defer b.Close()
+
+	call := NewHttpClientPool([]string{a.URL, b.URL}, LeastPending())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	var slow string
+	go func() {
+		defer wg.Done()
+		outcome, _ := call.Get(WithQueries(map[string]string{"slow": "1"}))
+		slow = string(outcome.Body)
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	counts := poolCounts(t, call, 4)
+	wg.Wait()
+	if counts[slow] != 0 {
+		t.Errorf("Expected requests to avoid the busy endpoint %s, got %v", slow, counts)
+	}
+}
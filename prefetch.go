@@ -0,0 +1,58 @@
+package easyrqst
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Prefetch sends a GET in the background only to populate the cache set in opts or
+// with WithDefaults. The response is dropped; a failure is passed to onError, which
+// may be nil.
+func (h *easyRequest) Prefetch(onError func(error), opts ...TReqOption) {
+	go func() {
+		if err := h.prefetch(opts...); err != nil && onError != nil {
+			onError(err)
+		}
+	}()
+}
+
+// PrefetchAll warms the cache with every request in requests, sending up to
+// concurrency of them at once, and returns the failures joined.
+func (h *easyRequest) PrefetchAll(concurrency int, requests ...[]TReqOption) error {
+	return prefetchAll(h.prefetch, concurrency, requests)
+}
+
+func (h *easyRequest) prefetch(opts ...TReqOption) error {
+	if cache := h.applyOptions(opts...).cacheObj; cache == nil || cache.fncs == nil {
+		return errors.New("request has no cache to prefetch into")
+	}
+	response, err := h.Get(opts...)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+	return nil
+}
+
+func prefetchAll(prefetch func(...TReqOption) error, concurrency int, requests [][]TReqOption) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	slots := make(chan struct{}, concurrency)
+	errs := make([]error, len(requests))
+	var wg sync.WaitGroup
+	for i, opts := range requests {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			errs[i] = prefetch(opts...)
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
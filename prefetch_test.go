@@ -0,0 +1,56 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPrefetch(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer server.Close()
+
+	cache := newMapCache()
+	call := NewHttpClient(server.URL, WithDefaults(WithCache(cache, time.Minute, "")))
+
+	failed := make(chan error, 1)
+	call.Prefetch(func(err error) { failed <- err }, WithPath("/missing"))
+	select {
+	case err := <-failed:
+		if !strings.Contains(err.Error(), "404") {
+			t.Errorf("Expected a 404 error, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the failed prefetch to be reported")
+	}
+
+	err := call.PrefetchAll(2, []TReqOption{WithPath("/a")}, []TReqOption{WithPath("/b")}, []TReqOption{WithPath("/c")})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	before := hits.Load()
+	for _, path := range []string{"/a", "/b", "/c"} {
+		response, err := call.Get(WithPath(path))
+		if err != nil || !response.FromCache || string(response.Body) != path {
+			t.Errorf("Expected %s to be served from the cache, got %+v, %v", path, response, err)
+		}
+	}
+	if hits.Load() != before {
+		t.Errorf("Expected no requests after warming, got %d", hits.Load()-before)
+	}
+
+	if err := NewHttpClient(server.URL).PrefetchAll(1, []TReqOption{WithPath("/a")}); err == nil {
+		t.Error("Expected an error when prefetching without a cache")
+	}
+}
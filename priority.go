@@ -0,0 +1,14 @@
+package easyrqst
+
+// Priority ranks requests when the client has to choose which traffic to drop or delay.
+type Priority int
+
+const (
+	PriorityLow Priority = iota - 1
+	PriorityNormal
+	PriorityHigh
+)
+
+func WithPriority(priority Priority) TReqOption {
+	return func(o *ReqOptions) { o.priority = priority }
+}
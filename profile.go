@@ -0,0 +1,46 @@
+package easyrqst
+
+import "fmt"
+
+// Profile is the configuration of one environment: its endpoint and the client options,
+// such as credentials and TLS settings, that only apply there.
+type Profile struct {
+	Endpoint string
+	Options  []THttpOption
+}
+
+type profileObj struct {
+	profiles map[string]Profile
+	active   string
+}
+
+// WithProfiles declares the per-environment configurations of the client. None of them
+// applies until one is selected with WithProfile.
+func WithProfiles(profiles map[string]Profile) THttpOption {
+	return func(o *easyRequest) { o.profiles.profiles = profiles }
+}
+
+// WithProfile selects the active profile, typically from the environment, e.g.
+// WithProfile(os.Getenv("APP_ENV")). Its endpoint replaces the one passed to the
+// constructor and its options are applied after the other client options. An empty name
+// keeps the defaults, an unknown one fails every request.
+func WithProfile(name string) THttpOption {
+	return func(o *easyRequest) { o.profiles.active = name }
+}
+
+func (h *easyRequest) applyProfile() {
+	if h.profiles.active == "" {
+		return
+	}
+	profile, ok := h.profiles.profiles[h.profiles.active]
+	if !ok {
+		h.initErr = fmt.Errorf("unknown profile %q", h.profiles.active)
+		return
+	}
+	if profile.Endpoint != "" {
+		h.endpoint = profile.Endpoint
+	}
+	for _, opt := range profile.Options {
+		opt(h)
+	}
+}
@@ -0,0 +1,46 @@
+package easyrqst
+
+import "testing"
+
+func TestProfile(t *testing.T) {
+	staging := newEchoAuthServer()
+	defer staging.Close()
+	production := newEchoAuthServer()
+	defer production.Close()
+
+	profiles := WithProfiles(map[string]Profile{
+		"staging":    {Endpoint: staging.URL, Options: []THttpOption{WithClientBasicAuth("neo", "matrix")}},
+		"production": {Endpoint: production.URL, Options: []THttpOption{WithDefaults(WithBearerToken("prod"))}},
+	})
+
+	outcome, err := NewHttpClient("", profiles, WithProfile("staging")).Get()
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if string(outcome.Body) != "Basic bmVvOm1hdHJpeA==" {
+		t.Errorf("Expected staging credentials, got %s", outcome.Body)
+	}
+
+	outcome, err = NewHttpClient("", profiles, WithProfile("production")).Get()
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if string(outcome.Body) != "Bearer prod" {
+		t.Errorf("Expected production credentials, got %s", outcome.Body)
+	}
+
+	outcome, err = NewHttpClient(staging.URL, profiles, WithProfile("")).Get()
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if len(outcome.Body) != 0 {
+		t.Errorf("Expected no profile credentials, got %s", outcome.Body)
+	}
+
+	if _, err := NewHttpClient(staging.URL, profiles, WithProfile("qa")).Get(); err == nil {
+		t.Errorf("Expected unknown profile error")
+	}
+}
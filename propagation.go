@@ -0,0 +1,54 @@
+package easyrqst
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type inboundKey struct{}
+
+// ContextWithInboundHeader makes the headers of an inbound request available to
+// outgoing requests made with ctx, see WithHeaderPropagation.
+func ContextWithInboundHeader(ctx context.Context, header http.Header) context.Context {
+	return context.WithValue(ctx, inboundKey{}, header)
+}
+
+// PropagationMiddleware stores the headers of every inbound request in its context.
+func PropagationMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(ContextWithInboundHeader(r.Context(), r.Header)))
+	})
+}
+
+// WithHeaderPropagation forwards inbound headers matching patterns, such as feature
+// flags, to outgoing requests. A pattern ending with * matches a prefix, e.g.
+// "X-Feature-*". The request context must carry the inbound headers, see
+// PropagationMiddleware. Headers set on the request itself are not overwritten.
+func WithHeaderPropagation(patterns ...string) THttpOption {
+	return WithDefaults(func(o *ReqOptions) {
+		o.editors = append(o.editors, func(req *http.Request) error {
+			inbound, _ := req.Context().Value(inboundKey{}).(http.Header)
+			for name, values := range inbound {
+				if req.Header.Get(name) == "" && matchesHeader(name, patterns) {
+					req.Header[name] = append([]string(nil), values...)
+				}
+			}
+			return nil
+		})
+	})
+}
+
+func matchesHeader(name string, patterns []string) bool {
+	name = http.CanonicalHeaderKey(name)
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(name, http.CanonicalHeaderKey(prefix)) {
+				return true
+			}
+		} else if name == http.CanonicalHeaderKey(pattern) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package easyrqst
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHeaderPropagation(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Join([]string{r.Header.Get("X-Feature-Checkout"), r.Header.Get("X-Feature-Search"), r.Header.Get("X-Tenant"), r.Header.Get("X-Other")}, "|")))
+	}))
+	defer upstream.Close()
+
+	call := NewHttpClient(upstream.URL, WithHeaderPropagation("x-feature-*", "X-Tenant"))
+	service := httptest.NewServer(PropagationMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		outcome, err := call.Get(WithContext(r.Context()), WithHeaders(map[string]string{"X-Feature-Search": "off"}))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		w.Write(outcome.Body)
+	})))
+	defer service.Close()
+
+	outcome, err := NewHttpClient(service.URL).Get(WithHeaders(map[string]string{
+		"X-Feature-Checkout": "v2", "X-Feature-Search": "on", "X-Tenant": "acme", "X-Other": "secret",
+	}))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if string(outcome.Body) != "v2|off|acme|" {
+		t.Errorf("Expected matching inbound headers to be forwarded, got %s", outcome.Body)
+	}
+}
+
+func TestHeaderPropagationCopiesValues(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer upstream.Close()
+
+	values := make([]string, 1, 4)
+	values[0] = "acme"
+	ctx := ContextWithInboundHeader(context.Background(), http.Header{"X-Tenant": values})
+	tag := func(o *ReqOptions) {
+		o.editors = append(o.editors, func(req *http.Request) error {
+			req.Header.Add("X-Tenant", "beta")
+			return nil
+		})
+	}
+	call := NewHttpClient(upstream.URL, WithHeaderPropagation("X-Tenant"))
+	if _, err := call.Get(WithContext(ctx), tag); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if shared := values[:2]; shared[1] != "" {
+		t.Errorf("Expected the inbound header to be left alone, got %v", shared)
+	}
+}
@@ -0,0 +1,53 @@
+package easyrqst
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestProxy(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.String() + " " + r.Header.Get("Proxy-Authorization")))
+	}))
+	defer proxy.Close()
+
+	call := NewHttpClient("http://upstream.internal/json", WithProxy("http://neo:matrix@"+proxy.Listener.Addr().String()))
+	outcome, err := call.Get()
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if string(outcome.Body) != "http://upstream.internal/json Basic bmVvOm1hdHJpeA==" {
+		t.Errorf("Expected request to go through the proxy, got %s", outcome.Body)
+	}
+
+	if _, err := NewHttpClient("http://upstream.internal", WithProxy("ftp://proxy")).Get(); err == nil {
+		t.Errorf("Expected unsupported scheme error")
+	}
+}
+
+func TestUnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "easyrqst.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Skipf("unix sockets unavailable: %v", err)
+	}
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Host + r.URL.Path))
+	}))
+	server.Listener = listener
+	server.Start()
+	defer server.Close()
+
+	outcome, err := NewHttpClient("http://docker/v1.43/containers/json", WithUnixSocket(socket)).Get()
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if string(outcome.Body) != "docker/v1.43/containers/json" {
+		t.Errorf("Expected host and path to be kept, got %s", outcome.Body)
+	}
+}
@@ -0,0 +1,137 @@
+package easyrqst
+
+import (
+	"container/heap"
+	"errors"
+	"sync"
+)
+
+var ErrQueueClosed = errors.New("request queue is closed")
+
+// QueueResult is the outcome of a queued request.
+type QueueResult struct {
+	Response *HttpResponse
+	Err      error
+}
+
+type queuedRequest struct {
+	method   string
+	priority Priority
+	seq      uint64
+	opts     []TReqOption
+	deliver  func(*HttpResponse, error)
+}
+
+// requestHeap orders queued requests by priority, then in the order they were queued.
+type requestHeap []*queuedRequest
+
+func (q requestHeap) Len() int { return len(q) }
+func (q requestHeap) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+func (q requestHeap) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *requestHeap) Push(x any)   { *q = append(*q, x.(*queuedRequest)) }
+func (q *requestHeap) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// RequestQueue sends requests in the background through a fixed pool of workers, higher
+// priorities first, smoothing out bursts of e.g. webhook or event deliveries.
+type RequestQueue struct {
+	client  IHttpClient
+	mu      sync.Mutex
+	cond    *sync.Cond
+	pending requestHeap
+	seq     uint64
+	closed  bool
+	workers sync.WaitGroup
+}
+
+// NewRequestQueue starts workers goroutines sending the queued requests with client.
+func NewRequestQueue(client IHttpClient, workers int) *RequestQueue {
+	if workers < 1 {
+		workers = 1
+	}
+	q := &RequestQueue{client: client}
+	q.cond = sync.NewCond(&q.mu)
+	q.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go q.work()
+	}
+	return q
+}
+
+// Enqueue queues a request and returns the channel its result is delivered on. The
+// priority is also set on the request, so it applies to load shedding as well.
+func (q *RequestQueue) Enqueue(priority Priority, method string, opts ...TReqOption) (<-chan QueueResult, error) {
+	results := make(chan QueueResult, 1)
+	err := q.EnqueueFunc(priority, method, func(response *HttpResponse, err error) {
+		results <- QueueResult{Response: response, Err: err}
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// EnqueueFunc queues a request and calls callback from a worker once it is done.
+func (q *RequestQueue) EnqueueFunc(priority Priority, method string, callback func(*HttpResponse, error), opts ...TReqOption) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+	q.seq++
+	heap.Push(&q.pending, &queuedRequest{
+		method:   method,
+		priority: priority,
+		seq:      q.seq,
+		opts:     append(opts[:len(opts):len(opts)], WithPriority(priority)),
+		deliver:  callback,
+	})
+	q.cond.Signal()
+	return nil
+}
+
+// Len returns the number of requests waiting for a worker.
+func (q *RequestQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pending.Len()
+}
+
+// Close stops accepting requests and waits until the queued ones are sent.
+func (q *RequestQueue) Close() {
+	q.mu.Lock()
+	q.closed = true
+	q.cond.Broadcast()
+	q.mu.Unlock()
+	q.workers.Wait()
+}
+
+func (q *RequestQueue) work() {
+	defer q.workers.Done()
+	for {
+		q.mu.Lock()
+		for q.pending.Len() == 0 && !q.closed {
+			q.cond.Wait()
+		}
+		if q.pending.Len() == 0 {
+			q.mu.Unlock()
+			return
+		}
+		item := heap.Pop(&q.pending).(*queuedRequest)
+		q.mu.Unlock()
+
+		response, err := q.client.Custom(item.method, item.opts...)
+		if item.deliver != nil {
+			item.deliver(response, err)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package easyrqst
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRequestQueue(t *testing.T) {
+	gate := make(chan struct{})
+	var mu sync.Mutex
+	var order []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/first" {
+			<-gate
+		}
+		mu.Lock()
+		order = append(order, r.URL.Path)
+		mu.Unlock()
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer server.Close()
+
+	queue := NewRequestQueue(NewHttpClient(server.URL), 1)
+	first, err := queue.Enqueue(PriorityNormal, http.MethodGet, WithPath("first"))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	// Wait until the only worker is busy with the first request
+	for queue.Len() != 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	var delivered []string
+	queue.EnqueueFunc(PriorityLow, http.MethodGet, func(response *HttpResponse, err error) {
+		delivered = append(delivered, string(response.Body))
+	}, WithPath("low"))
+	queue.Enqueue(PriorityNormal, http.MethodPost, WithPath("normal"))
+	queue.Enqueue(PriorityHigh, http.MethodGet, WithPath("high"))
+	if queue.Len() != 3 {
+		t.Errorf("Expected 3 pending requests, got %v", queue.Len())
+	}
+	close(gate)
+
+	if result := <-first; result.Err != nil || string(result.Response.Body) != "/first" {
+		t.Errorf("Expected first result, got %v %v", result.Response, result.Err)
+	}
+	queue.Close()
+
+	expected := []string{"/first", "/high", "/normal", "/low"}
+	for i, path := range expected {
+		if order[i] != path {
+			t.Errorf("Expected %v, got %v", expected, order)
+			break
+		}
+	}
+	if len(delivered) != 1 || delivered[0] != "/low" {
+		t.Errorf("Expected callback delivery, got %v", delivered)
+	}
+	if _, err := queue.Enqueue(PriorityHigh, http.MethodGet); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Expected ErrQueueClosed, got %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package easyrqst
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// WithRange requests the bytes from start to end, inclusive. A negative end reads to the
+// end of the resource. Calling it several times requests several ranges, which servers
+// answer with a multipart/byteranges body.
+func WithRange(start, end int64) TReqOption {
+	spec := fmt.Sprintf("%d-", start)
+	if end >= 0 {
+		spec += strconv.FormatInt(end, 10)
+	}
+	return func(o *ReqOptions) {
+		if current, ok := o.headers["Range"]; ok {
+			o.headers["Range"] = current + "," + spec
+		} else {
+			o.headers["Range"] = "bytes=" + spec
+		}
+	}
+}
+
+// ContentPart is one range of a resource. Size is the full size of the resource, -1 when
+// the server didn't tell.
+type ContentPart struct {
+	Start       int64
+	End         int64
+	Size        int64
+	ContentType string
+	Data        []byte
+}
+
+// PartialContent is the typed result of a range request.
+type PartialContent struct {
+	Parts []ContentPart
+}
+
+// PartialContent parses a 206 Partial Content response, single range or
+// multipart/byteranges. A 200 response is returned as a single part holding the whole
+// resource, as servers may ignore Range.
+func (h *HttpResponse) PartialContent() (*PartialContent, error) {
+	contentType := h.Header.Get("Content-Type")
+	switch h.StatusCode {
+	case http.StatusOK:
+		size := int64(len(h.Body))
+		return &PartialContent{Parts: []ContentPart{{Start: 0, End: size - 1, Size: size, ContentType: contentType, Data: h.Body}}}, nil
+	case http.StatusPartialContent:
+	case http.StatusRequestedRangeNotSatisfiable:
+		return nil, fmt.Errorf("range not satisfiable, content range %q", h.Header.Get("Content-Range"))
+	default:
+		return nil, fmt.Errorf("unexpected status code %d for a range request", h.StatusCode)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "multipart/byteranges" {
+		part := ContentPart{ContentType: contentType, Data: h.Body}
+		if err := parseContentRange(h.Header.Get("Content-Range"), &part); err != nil {
+			return nil, err
+		}
+		return &PartialContent{Parts: []ContentPart{part}}, nil
+	}
+
+	result := &PartialContent{}
+	reader := multipart.NewReader(bytes.NewReader(h.Body), params["boundary"])
+	for {
+		p, err := reader.NextPart()
+		if err == io.EOF {
+			return result, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read byteranges: %w", err)
+		}
+		data, err := io.ReadAll(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read byteranges: %w", err)
+		}
+		part := ContentPart{ContentType: p.Header.Get("Content-Type"), Data: data}
+		if err := parseContentRange(p.Header.Get("Content-Range"), &part); err != nil {
+			return nil, err
+		}
+		result.Parts = append(result.Parts, part)
+	}
+}
+
+// parseContentRange reads `bytes start-end/size`, size may be *.
+func parseContentRange(value string, part *ContentPart) error {
+	spec, ok := strings.CutPrefix(value, "bytes ")
+	if !ok {
+		return fmt.Errorf("invalid content range %q", value)
+	}
+	span, size, ok := strings.Cut(spec, "/")
+	first, last, ok2 := strings.Cut(span, "-")
+	if !ok || !ok2 {
+		return fmt.Errorf("invalid content range %q", value)
+	}
+
+	var err error
+	if part.Start, err = strconv.ParseInt(first, 10, 64); err != nil {
+		return fmt.Errorf("invalid content range %q", value)
+	}
+	if part.End, err = strconv.ParseInt(last, 10, 64); err != nil || part.End < part.Start {
+		return fmt.Errorf("invalid content range %q", value)
+	}
+	part.Size = -1
+	if size != "*" {
+		if part.Size, err = strconv.ParseInt(size, 10, 64); err != nil {
+			return fmt.Errorf("invalid content range %q", value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package easyrqst
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRangeRequests(t *testing.T) {
+	content := strings.NewReader("0123456789abcdefghij")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "artifact.txt", time.Time{}, content)
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL)
+	outcome, err := call.Get(WithRange(2, 5))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	partial, err := outcome.PartialContent()
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if len(partial.Parts) != 1 || string(partial.Parts[0].Data) != "2345" || partial.Parts[0].Start != 2 || partial.Parts[0].End != 5 || partial.Parts[0].Size != 20 {
+		t.Errorf("Unexpected single range %+v", partial.Parts)
+	}
+
+	outcome, err = call.Get(WithRange(0, 1), WithRange(18, -1))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if partial, err = outcome.PartialContent(); err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if len(partial.Parts) != 2 || string(partial.Parts[0].Data) != "01" || string(partial.Parts[1].Data) != "ij" || partial.Parts[1].Start != 18 {
+		t.Errorf("Unexpected multipart ranges %+v", partial.Parts)
+	}
+
+	outcome, err = call.Get(WithRange(50, -1))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if _, err := outcome.PartialContent(); err == nil {
+		t.Errorf("Expected unsatisfiable range error")
+	}
+
+	outcome, err = call.Get()
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if partial, err = outcome.PartialContent(); err != nil || !bytes.Equal(partial.Parts[0].Data, outcome.Body) {
+		t.Errorf("Expected full content as a single part, got %v", err)
+	}
+}
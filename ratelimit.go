@@ -0,0 +1,92 @@
+package easyrqst
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ILimiter paces outgoing requests. Wait blocks until a request may be sent or ctx is
+// done.
+type ILimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// WithRateLimiter makes every request, retries excluded, wait for the limiter first.
+func WithRateLimiter(limiter ILimiter) THttpOption {
+	return func(o *easyRequest) { o.limiter = limiter }
+}
+
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket allows rate requests per second on average and bursts of up to burst
+// requests. The bucket is local to the process.
+func NewTokenBucket(rate float64, burst int) (ILimiter, error) {
+	if err := checkBucket(rate, burst); err != nil {
+		return nil, err
+	}
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst)}, nil
+}
+
+func checkBucket(rate float64, burst int) error {
+	if !(rate > 0) {
+		return fmt.Errorf("rate limiter needs a positive rate, got %v", rate)
+	}
+	if burst < 1 {
+		return fmt.Errorf("rate limiter needs a burst of at least 1, got %d", burst)
+	}
+	return nil
+}
+
+// reserve takes a token and returns how long to wait before it can be used.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	if !b.last.IsZero() {
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// refund gives back the token of a request that won't be sent.
+func (b *tokenBucket) refund() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = min(b.burst, b.tokens+1)
+}
+
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	if err := sleepCtx(ctx, b.reserve()); err != nil {
+		b.refund()
+		return err
+	}
+	return nil
+}
+
+func sleepCtx(ctx context.Context, wait time.Duration) error {
+	if wait <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
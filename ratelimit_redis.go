@@ -0,0 +1,73 @@
+package easyrqst
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// TRedisEval runs a Lua script on Redis, like EVAL. With go-redis it is
+//
+//	func(ctx context.Context, script string, keys []string, args ...any) (any, error) {
+//		return rdb.Eval(ctx, script, keys, args...).Result()
+//	}
+type TRedisEval func(ctx context.Context, script string, keys []string, args ...any) (any, error)
+
+// redisTokenBucket refills the bucket from the Redis clock so every replica sees the
+// same state. It returns the milliseconds to wait, 0 when a token was taken.
+const redisTokenBucket = `
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or burst
+local ts = tonumber(state[2]) or now
+tokens = math.min(burst, tokens + (now - ts) * rate / 1000)
+local wait = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+else
+	wait = math.ceil((1 - tokens) * 1000 / rate)
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst * 1000 / rate) + 1000)
+return wait
+`
+
+type redisBucket struct {
+	eval  TRedisEval
+	key   string
+	rate  float64
+	burst int
+}
+
+// NewRedisTokenBucket shares a token bucket between processes through the Redis key, so
+// all replicas of a service collectively respect a third-party quota of rate requests
+// per second with bursts of up to burst. A request waiting for its turn doesn't hold a
+// token, so giving up on it takes nothing from the others.
+func NewRedisTokenBucket(eval TRedisEval, key string, rate float64, burst int) (ILimiter, error) {
+	if err := checkBucket(rate, burst); err != nil {
+		return nil, err
+	}
+	return &redisBucket{eval: eval, key: key, rate: rate, burst: burst}, nil
+}
+
+func (b *redisBucket) Wait(ctx context.Context) error {
+	for {
+		reply, err := b.eval(ctx, redisTokenBucket, []string{b.key}, b.rate, b.burst)
+		if err != nil {
+			return fmt.Errorf("rate limiter: %w", err)
+		}
+		wait, ok := reply.(int64)
+		if !ok {
+			return fmt.Errorf("rate limiter: unexpected reply %T", reply)
+		}
+		if wait <= 0 {
+			return nil
+		}
+		if err := sleepCtx(ctx, time.Duration(wait)*time.Millisecond); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package easyrqst
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	limiter, err := NewTokenBucket(50, 2)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	call := NewHttpClient(server.URL, WithRateLimiter(limiter))
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if _, err := call.Get(); err != nil {
+			t.Errorf("Error: %v", err)
+			return
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Errorf("Expected requests after the burst to be paced, took %v", elapsed)
+	}
+}
+
+func TestRedisTokenBucket(t *testing.T) {
+	replies := []int64{15, 0}
+	var calls []string
+	eval := func(ctx context.Context, script string, keys []string, args ...any) (any, error) {
+		calls = append(calls, keys[0])
+		if args[0] != 5.0 || args[1] != 10 {
+			t.Errorf("Unexpected args %v", args)
+		}
+		reply := replies[0]
+		replies = replies[1:]
+		return reply, nil
+	}
+
+	limiter, err := NewRedisTokenBucket(eval, "quota:github", 5, 10)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	start := time.Now()
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Errorf("Error: %v", err)
+	}
+	if len(calls) != 2 || calls[0] != "quota:github" || time.Since(start) < 15*time.Millisecond {
+		t.Errorf("Expected to wait as told by Redis and try again, got %v", calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	replies = []int64{1000}
+	if err := limiter.Wait(ctx); err != context.Canceled {
+		t.Errorf("Expected context error, got %v", err)
+	}
+}
+
+func TestTokenBucketValidationAndRefund(t *testing.T) {
+	for _, c := range []struct {
+		rate  float64
+		burst int
+	}{{0, 1}, {-1, 1}, {1, 0}} {
+		if _, err := NewTokenBucket(c.rate, c.burst); err == nil {
+			t.Errorf("Expected rate %v and burst %d to be rejected", c.rate, c.burst)
+		}
+		if _, err := NewRedisTokenBucket(nil, "quota", c.rate, c.burst); err == nil {
+			t.Errorf("Expected rate %v and burst %d to be rejected for Redis", c.rate, c.burst)
+		}
+	}
+
+	limiter, err := NewTokenBucket(1, 1)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(ctx); err != context.Canceled {
+			t.Errorf("Expected context error, got %v", err)
+		}
+	}
+	if tokens := limiter.(*tokenBucket).tokens; tokens < -0.5 {
+		t.Errorf("Expected canceled waits to give their token back, got %v tokens", tokens)
+	}
+}
@@ -0,0 +1,67 @@
+package easyrqst
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// writePins remembers recently written resources, by host and path, until when reads
+// of them skip the cache.
+type writePins struct {
+	window time.Duration
+	mu     sync.Mutex
+	until  map[string]time.Time
+}
+
+// WithReadYourWrites makes reads observe the client's own writes. For window after a
+// successful (2xx or 303) POST, PUT, PATCH or DELETE, GET and HEAD requests for the
+// written path and the paths below it skip the cache and ask intermediaries to
+// revalidate with Cache-Control: no-cache. The fresh response still goes to the cache.
+func WithReadYourWrites(window time.Duration) THttpOption {
+	return func(o *easyRequest) { o.writes = &writePins{window: window, until: make(map[string]time.Time)} }
+}
+
+func resourceOf(req *http.Request) string {
+	return req.URL.Host + "/" + strings.Trim(req.URL.Path, "/")
+}
+
+func (p *writePins) record(req *http.Request, response *HttpResponse) {
+	succeeded := (response.StatusCode >= 200 && response.StatusCode < 300) || response.StatusCode == http.StatusSeeOther
+	if p == nil || !succeeded {
+		return
+	}
+	switch req.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return
+	}
+
+	now := time.Now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for resource, until := range p.until {
+		if now.After(until) {
+			delete(p.until, resource)
+		}
+	}
+	p.until[resourceOf(req)] = now.Add(p.window)
+}
+
+func (p *writePins) pinned(req *http.Request) bool {
+	if p == nil || (req.Method != http.MethodGet && req.Method != http.MethodHead) {
+		return false
+	}
+
+	resource := resourceOf(req)
+	now := time.Now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for written, until := range p.until {
+		if now.Before(until) && (resource == written || strings.HasPrefix(resource, written+"/")) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestReadYourWrites(t *testing.T) {
+	name := "neo"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			name = "trinity"
+			return
+		}
+		w.Header().Set("X-Cache-Control", r.Header.Get("Cache-Control"))
+		w.Write([]byte(name))
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithReadYourWrites(50*time.Millisecond), WithDefaults(WithCache(newMapCache(), time.Minute, "v1")))
+	get := func(path string) *HttpResponse {
+		outcome, err := call.Get(WithPath(path))
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+		return outcome
+	}
+
+	get("users/1")
+	get("users")
+	if _, err := call.Custom(http.MethodPut, WithPath("users/1"), WithPayload(map[string]string{"name": "trinity"})); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	outcome := get("users/1")
+	if outcome.FromCache || string(outcome.Body) != "trinity" || outcome.Header.Get("X-Cache-Control") != "no-cache" {
+		t.Errorf("Expected a revalidated read after the write, got %s from cache: %v", outcome.Body, outcome.FromCache)
+	}
+	if outcome := get("users"); !outcome.FromCache {
+		t.Errorf("Expected unrelated resources to stay cached")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if outcome := get("users/1"); !outcome.FromCache || string(outcome.Body) != "trinity" {
+		t.Errorf("Expected the fresh response to be cached once the window passed, got %s", outcome.Body)
+	}
+}
@@ -0,0 +1,32 @@
+package easyrqst
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// TReauthFn refreshes the credentials of the client after the server rejected them.
+type TReauthFn func(ctx context.Context) error
+
+// WithReauth calls refresh when a request is answered with 401 or 403, then prepares
+// and sends the request once more, so token providers and signers pick up the new
+// credentials. A second rejection is returned as is. With a token source, refresh can
+// be as simple as calling its Invalidate method.
+func WithReauth(refresh TReauthFn) THttpOption {
+	return func(o *easyRequest) { o.reauth = refresh }
+}
+
+// reauthenticate reports whether the request has to be sent again with new credentials.
+func (h *easyRequest) reauthenticate(ctx context.Context, response *HttpResponse) (bool, error) {
+	if h.reauth == nil || response.FromCache {
+		return false, nil
+	}
+	if response.StatusCode != http.StatusUnauthorized && response.StatusCode != http.StatusForbidden {
+		return false, nil
+	}
+	if err := h.reauth(ctx); err != nil {
+		return false, fmt.Errorf("failed to refresh credentials: %w", err)
+	}
+	return true, nil
+}
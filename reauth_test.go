@@ -0,0 +1,51 @@
+package easyrqst
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReauth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	token, refreshes := "expired", 0
+	provider := WithTokenProvider(func(ctx context.Context) (string, error) { return token, nil })
+	call := NewHttpClient(server.URL, provider, WithReauth(func(ctx context.Context) error {
+		refreshes++
+		token = "fresh"
+		return nil
+	}))
+
+	outcome, err := call.Post(WithPayload(map[string]string{"a": "b"}))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if outcome.StatusCode != http.StatusOK || refreshes != 1 {
+		t.Errorf("Expected the request to be replayed after 1 refresh, got %v after %v", outcome.StatusCode, refreshes)
+	}
+
+	token = "revoked"
+	call = NewHttpClient(server.URL, provider, WithReauth(func(ctx context.Context) error {
+		refreshes++
+		return nil
+	}))
+	if outcome, err := call.Get(); err != nil || outcome.StatusCode != http.StatusUnauthorized || refreshes != 2 {
+		t.Errorf("Expected a single refresh and the second 401, got %v %v", outcome, err)
+	}
+
+	call = NewHttpClient(server.URL, provider, WithReauth(func(ctx context.Context) error {
+		return errors.New("refresh token expired")
+	}))
+	if _, err := call.Get(); err == nil {
+		t.Errorf("Expected refresh error")
+	}
+}
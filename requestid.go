@@ -0,0 +1,71 @@
+package easyrqst
+
+import (
+	"context"
+	"net/http"
+)
+
+type requestIDKey struct{}
+
+// ContextWithRequestID makes id the request ID of outgoing requests made with ctx, see
+// WithRequestID.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID set with ContextWithRequestID.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+// WithRequestID sets a request ID header, X-Request-ID unless changed with
+// WithRequestIDHeader, on every request. The ID comes from the context, either set with
+// ContextWithRequestID or found in the inbound headers stored by PropagationMiddleware,
+// and is otherwise generated with gen; a nil gen generates UUIDs. Retries keep the ID.
+func WithRequestID(gen func() string) THttpOption {
+	if gen == nil {
+		gen = newUUID
+	}
+	return func(o *easyRequest) {
+		WithDefaults(func(ro *ReqOptions) {
+			ro.editors = append(ro.editors, func(req *http.Request) error {
+				header := o.requestIDHeader
+				if header == "" {
+					header = "X-Request-ID"
+				}
+				if req.Header.Get(header) != "" {
+					return nil
+				}
+				id, ok := RequestIDFromContext(req.Context())
+				if !ok {
+					inbound, _ := req.Context().Value(inboundKey{}).(http.Header)
+					id = inbound.Get(header)
+				}
+				if id == "" {
+					id = gen()
+				}
+				req.Header.Set(header, id)
+				return nil
+			})
+		})(o)
+	}
+}
+
+func WithRequestIDHeader(name string) THttpOption {
+	return func(o *easyRequest) { o.requestIDHeader = name }
+}
+
+// WithCorrelation copies the string stored under key in the request context to header,
+// e.g. a traceparent put there by tracing middleware. Inbound headers are forwarded as
+// they are with WithHeaderPropagation.
+func WithCorrelation(header string, key any) THttpOption {
+	return WithDefaults(func(o *ReqOptions) {
+		o.editors = append(o.editors, func(req *http.Request) error {
+			if value, ok := req.Context().Value(key).(string); ok && value != "" && req.Header.Get(header) == "" {
+				req.Header.Set(header, value)
+			}
+			return nil
+		})
+	})
+}
@@ -0,0 +1,80 @@
+package easyrqst
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type traceKey struct{}
+
+func TestRequestID(t *testing.T) {
+	var ids []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids = append(ids, r.Header.Get("X-Request-ID"))
+		if len(ids) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(r.Header.Get("Traceparent")))
+	}))
+	defer server.Close()
+
+	n := 0
+	call := NewHttpClient(server.URL, WithRetryWaitMax(time.Millisecond), WithRequestID(func() string {
+		n++
+		return fmt.Sprintf("generated-%d", n)
+	}), WithCorrelation("traceparent", traceKey{}))
+
+	outcome, err := call.Get(WithContext(context.WithValue(context.Background(), traceKey{}, "00-trace-01")))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if len(ids) != 2 || ids[0] != "generated-1" || ids[1] != "generated-1" {
+		t.Errorf("Expected the retry to keep the generated id, got %v", ids)
+	}
+	if string(outcome.Body) != "00-trace-01" {
+		t.Errorf("Expected traceparent from the context, got %s", outcome.Body)
+	}
+
+	call.Get(WithContext(ContextWithRequestID(context.Background(), "from-context")))
+	if ids[2] != "from-context" {
+		t.Errorf("Expected the context id, got %v", ids[2])
+	}
+
+	proxy := httptest.NewServer(PropagationMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call.Get(WithContext(r.Context()))
+	})))
+	defer proxy.Close()
+	req, _ := http.NewRequest(http.MethodGet, proxy.URL, nil)
+	req.Header.Set("X-Request-ID", "inbound")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	resp.Body.Close()
+	if ids[3] != "inbound" {
+		t.Errorf("Expected the inbound id, got %v", ids[3])
+	}
+}
+
+func TestRequestIDHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Header.Get("X-Correlation-ID")))
+	}))
+	defer server.Close()
+
+	outcome, err := NewHttpClient(server.URL, WithRequestID(nil), WithRequestIDHeader("X-Correlation-ID")).Get()
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if len(outcome.Body) != 36 {
+		t.Errorf("Expected a generated UUID, got %s", outcome.Body)
+	}
+}
@@ -0,0 +1,162 @@
+package easyrqst
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/hashicorp/go-retryablehttp"
+	"io"
+	"net/http"
+	"time"
+)
+
+// TRetryPolicy decides whether a request is retried after each attempt. resp is nil
+// when the attempt failed with err.
+type TRetryPolicy func(ctx context.Context, resp *http.Response, err error) (bool, error)
+
+// DefaultRetryPolicy retries connection errors, 429 and 5xx responses (except 501).
+var DefaultRetryPolicy TRetryPolicy = retryablehttp.DefaultRetryPolicy
+
+func WithRetryPolicy(policy TRetryPolicy) THttpOption {
+	return func(o *easyRequest) { o.retryPolicy = policy }
+}
+
+type stateKey struct{}
+
+// requestState travels with the request context so the retry layer can reach the
+// options of the request it is retrying.
+type requestState struct {
+	options   *ReqOptions
+	retryable bool
+	attempts  int
+	start     time.Time
+	// request is the last attempt sent
+	request      *http.Request
+	attemptStart time.Time
+	results      []AttemptResult
+	// quiet attempts, those of mirrored requests, fire no hooks and aren't dumped
+	quiet bool
+}
+
+// AttemptResult is the outcome of one attempt of a request, before retrying. Err is the
+// transport error, StatusCode is 0 when there is one.
+type AttemptResult struct {
+	StatusCode int
+	Err        error
+	Latency    time.Duration
+}
+
+// markAttempt is called by retryablehttp right before every attempt.
+func markAttempt(_ retryablehttp.Logger, req *http.Request, _ int) {
+	stateFrom(req.Context()).attemptStart = time.Now()
+}
+
+// annotate returns a copy of response carrying the attempts made so far. Responses may
+// be shared between callers, so the caller's own attempts never go on the original.
+func (s *requestState) annotate(response *HttpResponse) *HttpResponse {
+	response = response.clone()
+	response.Attempts = len(s.results)
+	response.AttemptResults = s.results
+	response.TotalLatency = time.Since(s.start)
+	return response
+}
+
+func withState(req *http.Request, options *ReqOptions) *http.Request {
+	state := &requestState{options: options, retryable: options.retryNonIdempotent || idempotent(req), start: time.Now()}
+	return req.WithContext(context.WithValue(req.Context(), stateKey{}, state))
+}
+
+func stateFrom(ctx context.Context) *requestState {
+	if state, ok := ctx.Value(stateKey{}).(*requestState); ok {
+		return state
+	}
+	return &requestState{options: &ReqOptions{}, retryable: true}
+}
+
+// idempotent reports whether sending req twice has the same effect as sending it once.
+// Requests carrying an Idempotency-Key are deduplicated by the server.
+func idempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+// WithRetryNonIdempotent allows retrying POST, PATCH and other non-idempotent requests,
+// which are only sent once by default.
+func WithRetryNonIdempotent() TReqOption {
+	return func(o *ReqOptions) { o.retryNonIdempotent = true }
+}
+
+// WithExpect validates the response. A failed expectation is retried like a server
+// error, which helps with eventually consistent APIs returning incomplete data.
+func WithExpect(expect func(*HttpResponse) error) TReqOption {
+	return func(o *ReqOptions) { o.expect = append(o.expect, expect) }
+}
+
+func (h *easyRequest) checkRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	state := stateFrom(ctx)
+	result := AttemptResult{Err: err}
+	if resp != nil {
+		result.StatusCode = resp.StatusCode
+	}
+	if !state.attemptStart.IsZero() {
+		result.Latency = time.Since(state.attemptStart)
+	}
+	state.results = append(state.results, result)
+
+	if errors.Is(err, ErrPinMismatch) || errors.Is(err, ErrCircuitOpen) {
+		return false, err
+	}
+	var tooLarge *ResponseHeaderTooLargeError
+	var tooMany *TooManyResponseHeadersError
+	if errors.As(err, &tooLarge) || errors.As(err, &tooMany) {
+		return false, err
+	}
+	state.attempts++
+	retry, checkErr := h.shouldRetry(ctx, resp, err)
+	if retry && !state.retryable {
+		return false, checkErr
+	}
+	// Only spend the budget when retryablehttp has attempts left
+	if retry && h.retryBudget != nil && state.attempts <= h.maxRetry && !h.retryBudget.withdraw() {
+		return false, checkErr
+	}
+	if retry && state.attempts <= h.maxRetry {
+		h.hooks.fire(onRetry, HookEvent{Request: state.request, Response: resp, Err: err, Attempt: state.attempts, Elapsed: time.Since(state.start)})
+		h.metrics.retry(state.request)
+	}
+	return retry, checkErr
+}
+
+func (h *easyRequest) shouldRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	policy := h.retryPolicy
+	if policy == nil {
+		policy = DefaultRetryPolicy
+	}
+	retry, checkErr := policy(ctx, resp, err)
+	if retry || checkErr != nil || resp == nil {
+		return retry, checkErr
+	}
+
+	state := stateFrom(ctx)
+	if len(state.options.expect) == 0 {
+		return false, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return true, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	response := &HttpResponse{method: resp.Request.Method, StatusCode: resp.StatusCode, Header: resp.Header, Body: body}
+	for _, expect := range state.options.expect {
+		if err := expect(response); err != nil {
+			return true, err
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,164 @@
+package easyrqst
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestExpectRetries(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			return
+		}
+		w.Write([]byte("ready"))
+	}))
+	defer server.Close()
+
+	notEmpty := WithExpect(func(r *HttpResponse) error {
+		if len(r.Body) == 0 {
+			return errors.New("empty body")
+		}
+		return nil
+	})
+
+	call := NewHttpClient(server.URL, WithRetry(4), WithRetryWaitMax(time.Millisecond*10))
+	outcome, err := call.Get(notEmpty)
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if string(outcome.Body) != "ready" || calls != 3 {
+		t.Errorf("Expected body after 3 calls, got %q after %v calls", outcome.Body, calls)
+	}
+
+	calls = -10
+	call = NewHttpClient(server.URL, WithRetry(1), WithRetryWaitMax(time.Millisecond*10))
+	if _, err := call.Get(notEmpty); err == nil {
+		t.Errorf("Expected error once retries are exhausted")
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	onlyConflicts := func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		if resp != nil && resp.StatusCode == http.StatusConflict {
+			return true, nil
+		}
+		return false, nil
+	}
+
+	call := NewHttpClient(server.URL, WithRetry(5), WithRetryWaitMax(time.Millisecond*10), WithRetryPolicy(onlyConflicts))
+	outcome, err := call.Get()
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if outcome.StatusCode != http.StatusInternalServerError || calls != 3 {
+		t.Errorf("Expected 500 to stop retries after 3 calls, got %v after %v calls", outcome.StatusCode, calls)
+	}
+}
+
+func TestNonIdempotentRequestsAreNotRetried(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithRetry(2), WithRetryWaitMin(time.Millisecond))
+	cases := []struct {
+		opts     []TReqOption
+		expected int
+	}{
+		{nil, 1},
+		{[]TReqOption{WithRetryNonIdempotent()}, 3},
+		{[]TReqOption{WithHeaders(map[string]string{"Idempotency-Key": "order-1"})}, 3},
+	}
+	for _, c := range cases {
+		calls = 0
+		call.Post(c.opts...)
+		if calls != c.expected {
+			t.Errorf("Expected %v calls, got %v", c.expected, calls)
+		}
+	}
+
+	calls = 0
+	call.Custom(http.MethodPut)
+	if calls != 3 {
+		t.Errorf("Expected PUT to be retried, got %v calls", calls)
+	}
+}
+
+func TestAttemptMetadata(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch {
+		case r.URL.Path == "/old":
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+		case calls < 4:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithRetryWaitMax(time.Millisecond))
+	resp, err := call.Get(WithPath("old"))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if resp.Attempts != 2 || len(resp.AttemptResults) != 2 {
+		t.Errorf("Expected 2 attempts, got %v %+v", resp.Attempts, resp.AttemptResults)
+		return
+	}
+	if resp.AttemptResults[0].StatusCode != http.StatusServiceUnavailable || resp.AttemptResults[1].StatusCode != http.StatusOK || resp.AttemptResults[1].Latency <= 0 {
+		t.Errorf("Expected a 503 then a 200, got %+v", resp.AttemptResults)
+	}
+	if resp.TotalLatency < resp.AttemptResults[0].Latency+resp.AttemptResults[1].Latency {
+		t.Errorf("Expected the total latency to cover every attempt, got %v", resp.TotalLatency)
+	}
+	if resp.FinalURL != server.URL+"/new" {
+		t.Errorf("Expected the final URL after redirects, got %s", resp.FinalURL)
+	}
+}
+
+func TestAttemptMetadataPerCaller(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithSingleflight())
+	leader := make(chan *HttpResponse)
+	go func() {
+		resp, _ := call.Get()
+		leader <- resp
+	}()
+	time.Sleep(10 * time.Millisecond)
+	follower, err := call.Get()
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	first := <-leader
+	if first.Attempts != 1 || follower.Attempts != 0 {
+		t.Errorf("Expected the attempt to be on the leader only, got %d and %d", first.Attempts, follower.Attempts)
+	}
+}
@@ -0,0 +1,114 @@
+package easyrqst
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	retryBudgetBuckets = 10
+	// retryBudgetFloor is the number of retries always allowed per window, so clients
+	// with little traffic can still retry.
+	retryBudgetFloor = 10
+)
+
+// RetryBudgetStats are the counters of a retry budget. Requests and Retries cover the
+// current window, the totals cover the lifetime of the budget.
+type RetryBudgetStats struct {
+	Requests       int
+	Retries        int
+	TotalRequests  uint64
+	TotalRetries   uint64
+	TotalExhausted uint64
+	Exhausted      bool
+}
+
+type retryBucket struct {
+	start    time.Time
+	requests int
+	retries  int
+}
+
+// RetryBudget caps retries to a ratio of the requests made over a sliding window. Share
+// one budget between clients to cap them together.
+type RetryBudget struct {
+	mu      sync.Mutex
+	ratio   float64
+	window  time.Duration
+	buckets [retryBudgetBuckets]retryBucket
+	stats   RetryBudgetStats
+}
+
+// NewRetryBudget allows retries up to ratio (e.g. 0.2 for 20%) of the requests made in
+// the last window. The window is split in buckets and can't be shorter than
+// a microsecond.
+func NewRetryBudget(ratio float64, window time.Duration) (*RetryBudget, error) {
+	if window < time.Microsecond {
+		return nil, fmt.Errorf("retry budget window %s is shorter than a microsecond", window)
+	}
+	if ratio < 0 {
+		return nil, fmt.Errorf("retry budget ratio %v is negative", ratio)
+	}
+	return &RetryBudget{ratio: ratio, window: window}, nil
+}
+
+// WithRetryBudget stops retrying once the budget is spent, so a downstream outage does
+// not turn into a retry storm.
+func WithRetryBudget(budget *RetryBudget) THttpOption {
+	return func(o *easyRequest) { o.retryBudget = budget }
+}
+
+// bucket returns the bucket for now, recycling it if it belongs to an older window.
+func (b *RetryBudget) bucket(now time.Time) *retryBucket {
+	width := b.window / retryBudgetBuckets
+	start := now.Truncate(width)
+	bucket := &b.buckets[(start.UnixNano()/int64(width))%retryBudgetBuckets]
+	if !bucket.start.Equal(start) {
+		*bucket = retryBucket{start: start}
+	}
+	return bucket
+}
+
+// current sums the buckets that are still inside the window.
+func (b *RetryBudget) current(now time.Time) (requests, retries int) {
+	for _, bucket := range b.buckets {
+		if now.Sub(bucket.start) < b.window {
+			requests += bucket.requests
+			retries += bucket.retries
+		}
+	}
+	return requests, retries
+}
+
+func (b *RetryBudget) deposit() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bucket(time.Now()).requests++
+	b.stats.TotalRequests++
+}
+
+func (b *RetryBudget) withdraw() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	requests, retries := b.current(now)
+	if float64(retries+1) > b.ratio*float64(requests)+retryBudgetFloor {
+		b.stats.TotalExhausted++
+		return false
+	}
+	b.bucket(now).retries++
+	b.stats.TotalRetries++
+	return true
+}
+
+func (b *RetryBudget) Stats() RetryBudgetStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stats := b.stats
+	stats.Requests, stats.Retries = b.current(time.Now())
+	stats.Exhausted = float64(stats.Retries+1) > b.ratio*float64(stats.Requests)+retryBudgetFloor
+	return stats
+}
@@ -0,0 +1,61 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetryBudget(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	budget, err := NewRetryBudget(0, time.Minute)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	call := NewHttpClient(server.URL, WithRetry(5), WithRetryWaitMin(time.Millisecond), WithRetryWaitMax(time.Millisecond), WithRetryBudget(budget))
+	for i := 0; i < 3; i++ {
+		call.Get()
+	}
+
+	if calls != 13 {
+		t.Errorf("Expected retries to stop after the floor of the budget, got %v calls", calls)
+	}
+	stats := budget.Stats()
+	if stats.Requests != 3 || stats.Retries != 10 || stats.TotalExhausted != 1 || !stats.Exhausted {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+}
+
+func TestRetryBudgetWindow(t *testing.T) {
+	budget, _ := NewRetryBudget(0.5, time.Second)
+	for i := 0; i < 20; i++ {
+		budget.deposit()
+	}
+	for i := 0; i < 20; i++ {
+		budget.withdraw()
+	}
+	if stats := budget.Stats(); stats.Retries != 20 || stats.TotalExhausted != 0 {
+		t.Errorf("Expected 50%% of 20 requests plus the floor to be allowed, got %+v", stats)
+	}
+	if budget.withdraw() {
+		t.Errorf("Expected budget to be exhausted")
+	}
+}
+
+func TestRetryBudgetValidation(t *testing.T) {
+	for _, window := range []time.Duration{0, 9, -time.Second} {
+		if _, err := NewRetryBudget(0.2, window); err == nil {
+			t.Errorf("Expected window %v to be rejected", window)
+		}
+	}
+	if _, err := NewRetryBudget(-1, time.Second); err == nil {
+		t.Errorf("Expected a negative ratio to be rejected")
+	}
+}
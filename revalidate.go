@@ -0,0 +1,71 @@
+package easyrqst
+
+import (
+	"net/http"
+	"time"
+)
+
+// WithRevalidation keeps cached responses carrying an ETag or a Last-Modified date for
+// keep past their expiry. An expired entry is then revalidated with If-None-Match or
+// If-Modified-Since instead of downloaded again: on 304 Not Modified the entry is
+// extended and its body returned with FromCache set.
+func WithRevalidation(keep time.Duration) THttpOption {
+	return func(o *easyRequest) { o.revalidation = keep }
+}
+
+// cacheValidators are the conditional headers revalidating a cached response.
+func cacheValidators(header http.Header) map[string]string {
+	validators := make(map[string]string)
+	if etag := header.Get("ETag"); etag != "" {
+		validators["If-None-Match"] = etag
+	}
+	// Servers prefer If-None-Match when both are sent, so the date is only a fallback
+	if lastModified := header.Get("Last-Modified"); lastModified != "" {
+		validators["If-Modified-Since"] = lastModified
+	}
+	return validators
+}
+
+// keepFor is how long a response fresh for expiry stays in the cache.
+func (h *easyRequest) keepFor(response *HttpResponse, expiry time.Duration) time.Duration {
+	keep := h.storeExpiry(expiry)
+	if h.staleWindow > 0 && expiry > 0 {
+		keep += h.staleWindow
+	}
+	if h.revalidation > 0 && expiry > 0 && len(cacheValidators(response.Header)) > 0 {
+		keep += h.revalidation
+	}
+	return keep
+}
+
+// revalidatable reports whether a cached response is past its expiry and can be
+// revalidated.
+func (h *easyRequest) revalidatable(response *HttpResponse, expiry time.Duration) bool {
+	return h.revalidation > 0 && h.expired(response, expiry) && len(cacheValidators(response.Header)) > 0
+}
+
+func (h *easyRequest) revalidate(req *http.Request, options *ReqOptions, cached *HttpResponse) (*HttpResponse, error) {
+	conditional := req.Clone(req.Context())
+	for name, value := range cacheValidators(cached.Header) {
+		conditional.Header.Set(name, value)
+	}
+	if err := h.sign(conditional); err != nil {
+		return nil, err
+	}
+
+	response, err := h.fetch(conditional, options)
+	if err != nil || response.StatusCode != http.StatusNotModified {
+		return response, err
+	}
+	// A 304 carries the headers to update, the stored ones describe the body
+	for name, values := range response.Header {
+		if name != "Content-Length" {
+			cached.Header[name] = values
+		}
+	}
+	expiry, _ := h.cacheTTL(cached.StatusCode, options.cacheObj.expiry)
+	h.store(req, options.cacheObj, cached, expiry)
+	h.cacheDecision(CacheRevalidated, cached.cacheKey, nil)
+	cached.Timings = response.Timings
+	return cached, nil
+}
@@ -0,0 +1,106 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRevalidation(t *testing.T) {
+	etag, body := `"v1"`, "first"
+	var conditionals, downloads int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", etag)
+		w.Header().Set("X-Version", etag)
+		if r.Header.Get("If-None-Match") != "" {
+			conditionals++
+			if r.Header.Get("If-None-Match") == etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+		downloads++
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	var decisions []CacheDecision
+	cache := &expiryCache{mapCache: newMapCache(), expiries: make(map[string]time.Duration)}
+	call := NewHttpClient(server.URL, WithRevalidation(time.Minute), WithDefaults(WithCache(cache, 20*time.Millisecond, "")),
+		WithCacheHook(func(decision CacheDecision, key string, err error) { decisions = append(decisions, decision) }))
+	call.Get()
+	if expiry := cache.expiries["GET__?"]; expiry != time.Minute+20*time.Millisecond {
+		t.Errorf("Expected the entry kept for revalidation, got %v", expiry)
+	}
+
+	if resp, _ := call.Get(); !resp.FromCache || conditionals != 0 {
+		t.Errorf("Expected a fresh entry to be served without revalidation")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	resp, err := call.Get()
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if !resp.FromCache || resp.StatusCode != http.StatusOK || string(resp.Body) != "first" || conditionals != 1 || downloads != 1 {
+		t.Errorf("Expected a 304 to serve the cached body, got %v %d %q after %d conditionals", resp.FromCache, resp.StatusCode, resp.Body, conditionals)
+	}
+	if last := decisions[len(decisions)-1]; last != CacheRevalidated {
+		t.Errorf("Expected a revalidated decision, got %v", decisions)
+	}
+	if resp, _ := call.Get(); !resp.FromCache || conditionals != 1 {
+		t.Errorf("Expected the revalidated entry to be fresh again")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	etag, body = `"v2"`, "second"
+	resp, err = call.Get()
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if resp.FromCache || string(resp.Body) != "second" || downloads != 2 {
+		t.Errorf("Expected a changed resource to be downloaded, got %v %q", resp.FromCache, resp.Body)
+	}
+	if resp, _ := call.Get(); !resp.FromCache || string(resp.Body) != "second" || resp.Header.Get("X-Version") != `"v2"` {
+		t.Errorf("Expected the new version cached, got %v %q", resp.FromCache, resp.Body)
+	}
+}
+
+func TestRevalidationLastModified(t *testing.T) {
+	modified := time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)
+	var conditionals, downloads int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			t.Errorf("Expected no If-None-Match without an ETag")
+		}
+		if since := r.Header.Get("If-Modified-Since"); since != "" {
+			conditionals++
+			if since == modified {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+		downloads++
+		w.Header().Set("Last-Modified", modified)
+		w.Write([]byte("report"))
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithRevalidation(time.Minute), WithDefaults(WithCache(newMapCache(), 20*time.Millisecond, "")))
+	call.Get()
+	time.Sleep(30 * time.Millisecond)
+	resp, err := call.Get()
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if !resp.FromCache || string(resp.Body) != "report" || conditionals != 1 || downloads != 1 {
+		t.Errorf("Expected If-Modified-Since to revalidate the entry, got %v %q after %d conditionals", resp.FromCache, resp.Body, conditionals)
+	}
+	if resp.Header.Get("Last-Modified") != modified {
+		t.Errorf("Expected the stored Last-Modified to be kept, got %q", resp.Header.Get("Last-Modified"))
+	}
+}
@@ -0,0 +1,120 @@
+package easyrqst
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// hopHeaders apply to a single connection and are not forwarded by proxies.
+var hopHeaders = []string{
+	"Connection",
+	"Proxy-Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+func removeHopHeaders(header http.Header) {
+	for _, name := range header["Connection"] {
+		for _, field := range strings.Split(name, ",") {
+			header.Del(strings.TrimSpace(field))
+		}
+	}
+	for _, name := range hopHeaders {
+		header.Del(name)
+	}
+}
+
+// forwardRequest copies the path, query and headers of an inbound request. Its editor
+// runs before the ones of the client, so client auth replaces inbound credentials.
+func forwardRequest(in *http.Request) TReqOption {
+	return func(o *ReqOptions) {
+		o.ctx = in.Context()
+		// Keep escaped segments such as %2F as they came
+		o.path = in.URL.EscapedPath()
+		o.editors = append([]func(*http.Request) error{func(req *http.Request) error {
+			header := in.Header.Clone()
+			removeHopHeaders(header)
+			if ip, _, err := net.SplitHostPort(in.RemoteAddr); err == nil {
+				if prior := header.Get("X-Forwarded-For"); prior != "" {
+					ip = prior + ", " + ip
+				}
+				header.Set("X-Forwarded-For", ip)
+			}
+			for k, v := range header {
+				req.Header[k] = v
+			}
+			// Don't make up the JSON content type the client defaults to
+			if _, set := o.headers["Content-Type"]; !set && in.Header.Get("Content-Type") == "" {
+				req.Header.Del("Content-Type")
+			}
+
+			query := req.URL.Query()
+			for k, values := range in.URL.Query() {
+				for _, v := range values {
+					query.Add(k, v)
+				}
+			}
+			req.URL.RawQuery = query.Encode()
+			return nil
+		}}, o.editors...)
+	}
+}
+
+// DefaultMaxProxyBody is the request body size NewReverseProxy accepts by default.
+const DefaultMaxProxyBody = 10 << 20
+
+// NewReverseProxy forwards inbound requests to the client endpoint with the client's
+// retries, authentication and caching. The inbound path is appended to the endpoint;
+// wrap the handler in http.StripPrefix to mount it below a prefix. Request bodies are
+// buffered for retries, those over maxBody bytes (DefaultMaxProxyBody if 0) are
+// rejected with 413. Failed requests are answered with a plain 502, as their errors
+// name the upstream URL and its query credentials; onError, if not nil, is given the
+// actual error. opts are applied to every forwarded request.
+func NewReverseProxy(client IHttpClient, maxBody int64, onError func(*http.Request, error), opts ...TReqOption) http.Handler {
+	if maxBody <= 0 {
+		maxBody = DefaultMaxProxyBody
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBody))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		reqOpts := append([]TReqOption{forwardRequest(r)}, opts...)
+		if len(body) > 0 {
+			reqOpts = append(reqOpts, WithRawBody(body))
+		}
+		outcome, err := client.Custom(r.Method, reqOpts...)
+		if err != nil {
+			if onError != nil {
+				onError(r, err)
+			}
+			http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+			return
+		}
+
+		header := outcome.Header.Clone()
+		removeHopHeaders(header)
+		// The body may have been decompressed or unwrapped
+		header.Del("Content-Length")
+		for k, v := range header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(outcome.StatusCode)
+		w.Write(outcome.Body)
+	})
+}
@@ -0,0 +1,97 @@
+package easyrqst
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestReverseProxy(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Upstream", "1")
+		w.Header().Set("Connection", "close")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(strings.Join([]string{r.Method, r.URL.RequestURI(), r.Header.Get("Authorization"), r.Header.Get("X-Trace"), r.Header.Get("Content-Type"), string(body)}, "|")))
+	}))
+	defer upstream.Close()
+
+	client := NewHttpClient(upstream.URL+"/api", WithClientBasicAuth("neo", "matrix"))
+	gateway := httptest.NewServer(http.StripPrefix("/gateway", NewReverseProxy(client, 0, nil)))
+	defer gateway.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, gateway.URL+"/gateway/users?tag=a&tag=b", strings.NewReader("name=neo"))
+	req.Header.Set("Authorization", "Bearer inbound")
+	req.Header.Set("X-Trace", "abc")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	expected := "POST|/api/users?tag=a&tag=b|Basic bmVvOm1hdHJpeA==|abc|application/x-www-form-urlencoded|name=neo"
+	if string(body) != expected {
+		t.Errorf("Expected %s, got %s", expected, body)
+	}
+	if resp.StatusCode != http.StatusCreated || resp.Header.Get("X-Upstream") != "1" {
+		t.Errorf("Expected upstream status and headers, got %v %v", resp.StatusCode, resp.Header)
+	}
+}
+
+func TestReverseProxyUpstreamDown(t *testing.T) {
+	var failure error
+	client := NewHttpClient("http://127.0.0.1:1", WithRetry(0), WithDefaults(WithAPIKey("api_key", "SECRET123", InQuery)))
+	gateway := httptest.NewServer(NewReverseProxy(client, 0, func(r *http.Request, err error) { failure = err }))
+	defer gateway.Close()
+
+	resp, err := http.Get(gateway.URL)
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("Expected 502, got %v", resp.StatusCode)
+	}
+	if strings.Contains(string(body), "127.0.0.1") || strings.Contains(string(body), "SECRET123") {
+		t.Errorf("Expected the upstream URL not to be disclosed, got %s", body)
+	}
+	if failure == nil {
+		t.Errorf("Expected the error to be reported")
+	}
+}
+
+func TestReverseProxyLimitsAndEscapes(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.EscapedPath() + "|" + r.Header.Get("Content-Type")))
+	}))
+	defer upstream.Close()
+
+	gateway := httptest.NewServer(NewReverseProxy(NewHttpClient(upstream.URL, WithRetry(0)), 8, nil))
+	defer gateway.Close()
+
+	resp, err := http.Get(gateway.URL + "/files/a%2Fb")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != "/files/a%2Fb|" {
+		t.Errorf("Expected escaped segments to be kept without a content type, got %s", body)
+	}
+
+	resp, err = http.Post(gateway.URL, "text/plain", strings.NewReader("more than eight bytes"))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected 413 for a body over the limit, got %v", resp.StatusCode)
+	}
+}
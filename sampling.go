@@ -0,0 +1,34 @@
+package easyrqst
+
+import (
+	"math/rand"
+	"net/http"
+)
+
+// logSampling keeps a fraction of the request logs depending on their outcome.
+type logSampling struct {
+	errors  float64
+	success float64
+}
+
+// WithLogSampling keeps the given fraction (0.0 - 1.0) of failed and successful requests
+// in the logs and on the debug page, e.g. WithLogSampling(1, 0.01) logs every failure but
+// only one success in a hundred. A request fails on a transport error or a status of 400
+// and above. The per-attempt logs of the retry loop come before the outcome is known, so
+// they are turned off and the request log carries the status instead.
+func WithLogSampling(errors, success float64) THttpOption {
+	return func(o *easyRequest) { o.sampling = &logSampling{errors: errors, success: success} }
+}
+
+// keep reports whether a request with the given outcome is logged. Without sampling
+// every request is.
+func (s *logSampling) keep(statusCode int, err error) bool {
+	if s == nil {
+		return true
+	}
+	rate := s.success
+	if err != nil || statusCode >= http.StatusBadRequest {
+		rate = s.errors
+	}
+	return rand.Float64() < rate
+}
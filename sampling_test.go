@@ -0,0 +1,38 @@
+package easyrqst
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLogSampling(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	call := NewHttpClient(server.URL, WithRetry(0), WithLogger(log.New(&buf, "", 0)), WithLogSampling(1, 0))
+	for i := 0; i < 3; i++ {
+		call.Get()
+	}
+	for i := 0; i < 2; i++ {
+		call.Get(WithPath("fail"))
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected only the 2 failures to be logged, got %q", lines)
+	}
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "REQUEST_TIME url=/fail method=GET status=404") {
+			t.Errorf("Expected failed request log, got %s", line)
+		}
+	}
+}
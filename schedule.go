@@ -0,0 +1,76 @@
+package easyrqst
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrScheduleCanceled = errors.New("scheduled request canceled")
+
+// ScheduledRequest is a handle on a request that fires later.
+type ScheduledRequest struct {
+	mu       sync.Mutex
+	timer    *time.Timer
+	done     chan struct{}
+	fired    bool
+	response *HttpResponse
+	err      error
+}
+
+func schedule(at time.Time, send func() (*HttpResponse, error)) *ScheduledRequest {
+	s := &ScheduledRequest{done: make(chan struct{})}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.timer = time.AfterFunc(time.Until(at), func() {
+		s.mu.Lock()
+		if s.fired {
+			s.mu.Unlock()
+			return
+		}
+		s.fired = true
+		s.mu.Unlock()
+
+		s.response, s.err = send()
+		close(s.done)
+	})
+	return s
+}
+
+// Cancel stops the request if it hasn't fired yet and reports whether it did. A request
+// already sent runs to completion.
+func (s *ScheduledRequest) Cancel() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fired {
+		return false
+	}
+	s.fired = true
+	s.timer.Stop()
+	s.err = ErrScheduleCanceled
+	close(s.done)
+	return true
+}
+
+// Done is closed once the request completed or was canceled.
+func (s *ScheduledRequest) Done() <-chan struct{} {
+	return s.done
+}
+
+// Result waits for the request and returns its outcome, ErrScheduleCanceled if it was
+// canceled before firing.
+func (s *ScheduledRequest) Result() (*HttpResponse, error) {
+	<-s.done
+	return s.response, s.err
+}
+
+// Schedule sends the request at the given time, through the usual retry and cache
+// pipeline. A time in the past fires right away.
+func (h *easyRequest) Schedule(at time.Time, method string, opts ...TReqOption) *ScheduledRequest {
+	return schedule(at, func() (*HttpResponse, error) { return h.Custom(method, opts...) })
+}
+
+// After sends the request once d has elapsed.
+func (h *easyRequest) After(d time.Duration, method string, opts ...TReqOption) *ScheduledRequest {
+	return h.Schedule(time.Now().Add(d), method, opts...)
+}
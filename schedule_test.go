@@ -0,0 +1,49 @@
+package easyrqst
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSchedule(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte(r.Method))
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL)
+	start := time.Now()
+	scheduled := call.After(50*time.Millisecond, http.MethodPost)
+	outcome, err := scheduled.Result()
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("Expected the request to be delayed, fired after %v", elapsed)
+	}
+	if string(outcome.Body) != http.MethodPost {
+		t.Errorf("Expected POST, got %s", outcome.Body)
+	}
+	if scheduled.Cancel() {
+		t.Errorf("Expected a fired request not to be canceled")
+	}
+
+	canceled := call.Schedule(time.Now().Add(time.Hour), http.MethodGet)
+	if !canceled.Cancel() {
+		t.Errorf("Expected a pending request to be canceled")
+	}
+	<-canceled.Done()
+	if _, err := canceled.Result(); !errors.Is(err, ErrScheduleCanceled) {
+		t.Errorf("Expected ErrScheduleCanceled, got %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Expected 1 call, got %v", calls.Load())
+	}
+}
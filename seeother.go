@@ -0,0 +1,73 @@
+package easyrqst
+
+import (
+	"fmt"
+	"net/http"
+)
+
+const maxSeeOther = 10
+
+// checkRedirect stops at 303 See Other so the client can expose it, other redirects are
+// followed like net/http does. net/http only drops Authorization and cookies when the
+// host changes, so the other credentials are stripped here; redirects on the same host
+// are signed again for their new URL.
+func (h *easyRequest) checkRedirect(req *http.Request, via []*http.Request) error {
+	if req.Response != nil && req.Response.StatusCode == http.StatusSeeOther {
+		return http.ErrUseLastResponse
+	}
+	if len(via) >= 10 {
+		// retryablehttp recognizes the message by its ending and doesn't retry it
+		return fmt.Errorf("%w: stopped after 10 redirects", ErrTooManyRedirects)
+	}
+	state := stateFrom(req.Context())
+	if req.URL.Host != via[0].URL.Host {
+		h.stripCredentials(req, state.options)
+		return nil
+	}
+	if state.quiet {
+		// Mirrored requests are sent unsigned
+		return nil
+	}
+	return h.sign(req)
+}
+
+// followSeeOther fetches the Location of a 303 See Other with a GET, as async job APIs
+// expect ("created, poll here"). The 303 is kept on the result as Intermediate.
+func (h *easyRequest) followSeeOther(req *http.Request, options *ReqOptions, response *HttpResponse) (*HttpResponse, error) {
+	origin, trusted := req.URL.Host, true
+	for i := 0; response.StatusCode == http.StatusSeeOther; i++ {
+		if i == maxSeeOther {
+			return response, fmt.Errorf("stopped after %d see other redirects", maxSeeOther)
+		}
+		location, err := req.URL.Parse(response.Header.Get("Location"))
+		if err != nil || response.Header.Get("Location") == "" {
+			return response, fmt.Errorf("see other without a valid location: %q", response.Header.Get("Location"))
+		}
+
+		next, err := http.NewRequestWithContext(req.Context(), http.MethodGet, location.String(), nil)
+		if err != nil {
+			return response, err
+		}
+		next.Header = req.Header.Clone()
+		next.Header.Del("Content-Type")
+		next.Header.Del("Content-Length")
+		// Once redirected to another host, credentials are neither forwarded nor
+		// signed again, even if a later hop comes back
+		if trusted = trusted && location.Host == origin; !trusted {
+			h.stripCredentials(next, options)
+		} else if err := h.sign(next); err != nil {
+			return response, err
+		}
+
+		intermediate := response
+		followOptions := *options
+		followOptions.cacheObj = nil
+		if response, err = h.handle(next, &followOptions); err != nil {
+			return response, err
+		}
+		response = response.clone()
+		response.Intermediate = intermediate
+		req = next
+	}
+	return response, nil
+}
@@ -0,0 +1,113 @@
+package easyrqst
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPostSeeOther(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/jobs":
+			w.Header().Set("Location", "/jobs/42")
+			w.WriteHeader(http.StatusSeeOther)
+			w.Write([]byte("created"))
+		case "/jobs/42":
+			body, _ := io.ReadAll(r.Body)
+			w.Write([]byte(r.Method + "|" + r.Header.Get("Authorization") + "|" + string(body)))
+		}
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithClientBasicAuth("neo", "matrix"))
+	outcome, err := call.Post(WithPath("/jobs"), WithPayload(map[string]string{"report": "daily"}))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if string(outcome.Body) != "GET|Basic bmVvOm1hdHJpeA==|" {
+		t.Errorf("Expected GET to the location without a body, got %s", outcome.Body)
+	}
+	if outcome.Intermediate == nil || outcome.Intermediate.StatusCode != http.StatusSeeOther || string(outcome.Intermediate.Body) != "created" {
+		t.Errorf("Expected intermediate 303 response, got %+v", outcome.Intermediate)
+	}
+}
+
+func TestSeeOtherCrossHostCredentials(t *testing.T) {
+	credentials := []string{"Authorization", "Proxy-Authorization", "X-Api-Key", "X-Signature", "X-Timestamp"}
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var leaked []string
+		for _, name := range credentials {
+			if r.Header.Get(name) != "" {
+				leaked = append(leaked, name)
+			}
+		}
+		w.Write([]byte(strings.Join(leaked, ",")))
+	}))
+	defer other.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", other.URL+"/jobs/42")
+		w.WriteHeader(http.StatusSeeOther)
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithClientBasicAuth("neo", "matrix"), WithSigner(NewHMACSigner([]byte("k"), HMACConfig{})))
+	outcome, err := call.Post(WithAPIKey("X-Api-Key", "s3cr3t", InHeader), WithHeaders(map[string]string{"Proxy-Authorization": "Basic cHJveHk="}))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if len(outcome.Body) != 0 {
+		t.Errorf("Expected no credentials to reach another host, got %s", outcome.Body)
+	}
+}
+
+func TestRedirectCrossHostCredentials(t *testing.T) {
+	credentials := []string{"Authorization", "X-Api-Key", "X-Signature", "X-Timestamp"}
+	headers := func(r *http.Request) string {
+		var sent []string
+		for _, name := range credentials {
+			if r.Header.Get(name) != "" {
+				sent = append(sent, name)
+			}
+		}
+		return strings.Join(sent, ",")
+	}
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(headers(r) + "|" + r.URL.RawQuery))
+	}))
+	defer other.Close()
+	var signatures []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signatures = append(signatures, r.Header.Get("X-Signature"))
+		switch r.URL.Path {
+		case "/moved":
+			http.Redirect(w, r, "/here", http.StatusFound)
+		case "/here":
+			w.Write([]byte(headers(r)))
+		default:
+			http.Redirect(w, r, other.URL+"/elsewhere?"+r.URL.RawQuery, http.StatusTemporaryRedirect)
+		}
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithClientBasicAuth("neo", "matrix"), WithSigner(NewHMACSigner([]byte("k"), HMACConfig{})))
+	outcome, err := call.Get(WithAPIKey("X-Api-Key", "s3cr3t", InHeader), WithAPIKey("api_key", "qu3ry", InQuery))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if string(outcome.Body) != "|" {
+		t.Errorf("Expected no credentials to follow a redirect to another host, got %s", outcome.Body)
+	}
+
+	signatures = nil
+	outcome, err = call.Get(WithPath("/moved"), WithAPIKey("X-Api-Key", "s3cr3t", InHeader))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if string(outcome.Body) != strings.Join(credentials, ",") || len(signatures) != 2 || signatures[0] == signatures[1] {
+		t.Errorf("Expected redirects on the same host to keep credentials and be signed again, got %s %v", outcome.Body, signatures)
+	}
+}
@@ -0,0 +1,172 @@
+package easyrqst
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TSampler decides whether a request is mirrored to the shadow endpoint.
+type TSampler func(req *http.Request) bool
+
+type shadowObj struct {
+	endpoint    string
+	sampler     TSampler
+	hook        func(*ShadowResult)
+	credentials bool
+}
+
+// ShadowResult describes how the shadow endpoint answered compared to the primary one.
+type ShadowResult struct {
+	Endpoint   string
+	Primary    *HttpResponse
+	Shadow     *HttpResponse
+	Err        error
+	StatusDiff bool
+	BodyDiff   bool
+}
+
+func (s *ShadowResult) Diff() bool {
+	return s.Err != nil || s.StatusDiff || s.BodyDiff
+}
+
+// SampleRate mirrors roughly the given fraction (0.0 - 1.0) of requests.
+func SampleRate(rate float64) TSampler {
+	return func(*http.Request) bool { return rand.Float64() < rate }
+}
+
+// WithShadow mirrors requests picked by sampler to a secondary endpoint in the
+// background. A nil sampler mirrors every request.
+func WithShadow(endpoint string, sampler TSampler) THttpOption {
+	return func(o *easyRequest) {
+		o.shadow.endpoint = endpoint
+		o.shadow.sampler = sampler
+	}
+}
+
+func WithShadowHook(hook func(*ShadowResult)) THttpOption {
+	return func(o *easyRequest) { o.shadow.hook = hook }
+}
+
+// WithShadowCredentials sends the credentials of mirrored requests to the shadow
+// endpoint as well. They are stripped by default, as it usually is another host.
+func WithShadowCredentials() THttpOption {
+	return func(o *easyRequest) { o.shadow.credentials = true }
+}
+
+// credentialHeaders are the headers net/http drops when a redirect leaves the host, and
+// proxy credentials.
+var credentialHeaders = []string{"Authorization", "Www-Authenticate", "Cookie", "Cookie2", "Proxy-Authorization"}
+
+// headerSigner is implemented by signers that can tell which headers they set.
+type headerSigner interface {
+	signatureHeaders() []string
+}
+
+// stripCredentials removes the credentials of a request made with options, for sending
+// it to another host: the usual credential headers, API keys and signatures.
+func (h *easyRequest) stripCredentials(req *http.Request, options *ReqOptions) {
+	for _, name := range credentialHeaders {
+		req.Header.Del(name)
+	}
+	for _, name := range options.secretHeaders {
+		req.Header.Del(name)
+	}
+	if signer, ok := h.signer.(headerSigner); ok {
+		for _, name := range signer.signatureHeaders() {
+			req.Header.Del(name)
+		}
+	}
+	if len(options.secretParams) > 0 {
+		query := req.URL.Query()
+		for _, name := range options.secretParams {
+			query.Del(name)
+		}
+		req.URL.RawQuery = query.Encode()
+	}
+}
+
+// shadowPath is the path of req below the shadow endpoint: the part after the path of
+// the client endpoint, or the whole path for URLs outside of it.
+func (h *easyRequest) shadowPath(req *http.Request, base *url.URL) string {
+	path := req.URL.EscapedPath()
+	if endpoint, err := url.Parse(h.endpoint); err == nil {
+		if prefix := strings.TrimRight(endpoint.EscapedPath(), "/"); prefix != "" && (path == prefix || strings.HasPrefix(path, prefix+"/")) {
+			path = strings.TrimPrefix(path, prefix)
+		}
+	}
+	return strings.TrimRight(base.EscapedPath(), "/") + path
+}
+
+func (h *easyRequest) mirror(req *http.Request, options *ReqOptions, primary *HttpResponse) {
+	if h.shadow.endpoint == "" || (h.shadow.sampler != nil && !h.shadow.sampler(req)) {
+		return
+	}
+
+	result := &ShadowResult{Endpoint: h.shadow.endpoint, Primary: primary}
+	report := func() {
+		if h.shadow.hook != nil {
+			h.shadow.hook(result)
+		}
+	}
+
+	target, err := url.Parse(h.shadow.endpoint)
+	if err != nil {
+		result.Err = err
+		go report()
+		return
+	}
+	path := h.shadowPath(req, target)
+	if target.Path, err = url.PathUnescape(path); err != nil {
+		result.Err = err
+		go report()
+		return
+	}
+	target.RawPath = path
+	target.RawQuery = req.URL.RawQuery
+
+	shadowReq := req.Clone(context.Background())
+	shadowReq.URL = target
+	shadowReq.Host = target.Host
+	if !h.shadow.credentials {
+		h.stripCredentials(shadowReq, options)
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			result.Err = err
+			go report()
+			return
+		}
+		shadowReq.Body = body
+	}
+
+	go func() {
+		defer report()
+
+		// A single attempt, out of sight of the caller's hooks and dumps
+		state := &requestState{options: options, start: time.Now(), quiet: true}
+		shadowReq = shadowReq.WithContext(context.WithValue(shadowReq.Context(), stateKey{}, state))
+		shadowClient := &http.Client{Transport: h.attempts, CheckRedirect: h.checkRedirect, Timeout: h.timeout}
+		resp, err := shadowClient.Do(shadowReq)
+		if err != nil {
+			result.Err = err
+			return
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			result.Err = err
+			return
+		}
+		result.Shadow = &HttpResponse{method: req.Method, StatusCode: resp.StatusCode, Body: body}
+		result.StatusDiff = primary.StatusCode != resp.StatusCode
+		result.BodyDiff = !bytes.Equal(primary.Body, body)
+	}()
+}
@@ -0,0 +1,137 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestShadowReportsDiff(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"version":1}`))
+	}))
+	defer primary.Close()
+	secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("id") != "7" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		w.Write([]byte(`{"version":2}`))
+	}))
+	defer secondary.Close()
+
+	results := make(chan *ShadowResult, 1)
+	call := NewHttpClient(primary.URL, WithShadow(secondary.URL, nil), WithShadowHook(func(r *ShadowResult) { results <- r }))
+
+	outcome, err := call.Get(WithQueries(map[string]string{"id": "7"}))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if string(outcome.Body) != `{"version":1}` {
+		t.Errorf("Expected primary body, got %s", outcome.Body)
+	}
+
+	select {
+	case r := <-results:
+		if r.Err != nil {
+			t.Errorf("Error: %v", r.Err)
+		}
+		if r.StatusDiff {
+			t.Errorf("Expected matching status codes, got %v", r.Shadow.StatusCode)
+		}
+		if !r.BodyDiff || !r.Diff() {
+			t.Errorf("Expected body diff to be reported")
+		}
+	case <-time.After(time.Second * 5):
+		t.Errorf("Shadow hook was not called")
+	}
+}
+
+func TestShadowSampler(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	called := make(chan struct{}, 1)
+	call := NewHttpClient(server.URL, WithShadow(server.URL, SampleRate(0)), WithShadowHook(func(*ShadowResult) { called <- struct{}{} }))
+	if _, err := call.Get(); err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+
+	select {
+	case <-called:
+		t.Errorf("Expected request not to be mirrored")
+	case <-time.After(time.Millisecond * 100):
+	}
+}
+
+func TestShadowPathAndCredentials(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer primary.Close()
+	received := make(chan *http.Request, 2)
+	secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r
+	}))
+	defer secondary.Close()
+
+	for _, allowed := range []bool{false, true} {
+		opts := []THttpOption{WithShadow(secondary.URL+"/v2", nil), WithClientBasicAuth("neo", "matrix")}
+		if allowed {
+			opts = append(opts, WithShadowCredentials())
+		}
+		call := NewHttpClient(primary.URL+"/v1", opts...)
+		if _, err := call.Get(WithPath("files/a%2Fb"), WithAPIKey("X-Api-Key", "k3y", InHeader), WithAPIKey("key", "q", InQuery)); err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+
+		select {
+		case r := <-received:
+			if r.URL.EscapedPath() != "/v2/files/a%2Fb" {
+				t.Errorf("Expected the request path below the shadow endpoint, got %s", r.URL.EscapedPath())
+			}
+			credentials := r.Header.Get("Authorization") != "" || r.Header.Get("X-Api-Key") != "" || r.URL.Query().Get("key") != ""
+			if credentials != allowed {
+				t.Errorf("Expected credentials to be forwarded only when allowed (%v), got %v %v", allowed, r.Header, r.URL)
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatalf("Request was not mirrored")
+		}
+	}
+}
+
+func TestShadowSingleQuietAttempt(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer primary.Close()
+	var hits atomic.Int32
+	var signed atomic.Bool
+	secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		signed.Store(signed.Load() || r.Header.Get("X-Signature") != "")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer secondary.Close()
+
+	var requests atomic.Int32
+	results := make(chan *ShadowResult, 1)
+	call := NewHttpClient(primary.URL, WithRetry(2), WithRetryWaitMax(time.Millisecond),
+		WithSigner(NewHMACSigner([]byte("k"), HMACConfig{})),
+		WithHooks(Hooks{OnRequest: func(HookEvent) { requests.Add(1) }}),
+		WithShadow(secondary.URL, nil), WithShadowHook(func(r *ShadowResult) { results <- r }))
+	if _, err := call.Get(); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	select {
+	case result := <-results:
+		if result.Shadow == nil || result.Shadow.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("Expected the shadow response, got %+v", result)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatalf("Request was not mirrored")
+	}
+	if hits.Load() != 1 || requests.Load() != 1 || signed.Load() {
+		t.Errorf("Expected one unsigned shadow attempt without hooks, got %d attempts, %d hooks, signed %v", hits.Load(), requests.Load(), signed.Load())
+	}
+}
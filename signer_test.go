@@ -0,0 +1,70 @@
+package easyrqst
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHMACSigner(t *testing.T) {
+	key := []byte("secret")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		sum := sha256.Sum256(body)
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(strings.Join([]string{r.Method, r.URL.RequestURI(), r.Header.Get("X-Timestamp"), r.Header.Get("X-Nonce"), hex.EncodeToString(sum[:])}, "\n")))
+		if !hmac.Equal([]byte(hex.EncodeToString(mac.Sum(nil))), []byte(r.Header.Get("X-Signature"))) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(r.Header.Get("X-Key-Id") + "@" + r.Header.Get("X-Timestamp")))
+	}))
+	defer server.Close()
+
+	clock := func() time.Time { return time.Unix(1700000000, 0) }
+	signer := NewHMACSigner(key, HMACConfig{KeyID: "k1"})
+	call := NewHttpClient(server.URL, WithSigner(signer), WithClock(clock))
+
+	outcome, err := call.Post(WithPayload(generalPayload), WithQueries(map[string]string{"q": "1"}))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if outcome.StatusCode != http.StatusOK {
+		t.Errorf("Expected status code 200, got %v", outcome.StatusCode)
+	}
+	if string(outcome.Body) != "k1@1700000000" {
+		t.Errorf("Expected key id and client clock timestamp, got %s", outcome.Body)
+	}
+}
+
+func TestHMACSignerRetries(t *testing.T) {
+	var nonces []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nonces = append(nonces, r.Header.Get("X-Nonce"))
+		if len(nonces) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithRetry(1), WithRetryWaitMax(time.Millisecond), WithSigner(NewHMACSigner([]byte("k"), HMACConfig{})))
+	outcome, err := call.Get()
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if outcome.StatusCode != http.StatusOK || len(nonces) != 2 || nonces[0] == "" || nonces[0] == nonces[1] {
+		t.Errorf("Expected the retry to be signed with a new nonce, got %v", nonces)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err := NewHMACSigner([]byte("k"), HMACConfig{}).Sign(req, nil); err != nil || req.Header.Get("X-Timestamp") == "" {
+		t.Errorf("Expected a signer without a client to use the system clock, got %v", err)
+	}
+}
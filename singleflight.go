@@ -0,0 +1,56 @@
+package easyrqst
+
+import (
+	"net/http"
+	"sync"
+)
+
+type flightCall struct {
+	done     chan struct{}
+	response *HttpResponse
+	err      error
+}
+
+// flightGroup runs one call per key at a time, concurrent callers wait for its result.
+// Every caller gets its own copy of the response, as they go on to set fields on it.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+// WithSingleflight makes concurrent identical GET and HEAD requests (same URL, query and
+// cache key) share a single request to the origin. Every caller gets the same response,
+// which must not be modified, and the first caller's context applies to all of them.
+func WithSingleflight() THttpOption {
+	return func(o *easyRequest) { o.flights = &flightGroup{calls: make(map[string]*flightCall)} }
+}
+
+func (g *flightGroup) do(key string, fn func() (*HttpResponse, error)) (*HttpResponse, error) {
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		<-call.done
+		return call.response.clone(), call.err
+	}
+	call := &flightCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(call.done)
+	}()
+	call.response, call.err = fn()
+	return call.response.clone(), call.err
+}
+
+func (h *easyRequest) flightKey(req *http.Request, options *ReqOptions) string {
+	cache := options.cacheObj
+	if cache == nil {
+		cache = &cacheObj{}
+	}
+	// Custom cache keys may leave out what tells requests apart, so flights keep the default
+	return req.URL.Host + "_" + req.Header.Get("Range") + "_" + h.namespace + "_" + defaultCacheKey(req, cache)
+}
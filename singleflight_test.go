@@ -0,0 +1,53 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSingleflight(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		time.Sleep(30 * time.Millisecond)
+		w.Write([]byte(r.URL.Query().Get("id")))
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithSingleflight())
+	var wg sync.WaitGroup
+	bodies := make([]string, 6)
+	responses := make([]*HttpResponse, 6)
+	for i := range bodies {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id := "1"
+			if i == 5 {
+				id = "2"
+			}
+			outcome, err := call.Get(WithQueries(map[string]string{"id": id}))
+			if err != nil {
+				t.Errorf("Error: %v", err)
+				return
+			}
+			bodies[i] = string(outcome.Body)
+			responses[i] = outcome
+		}(i)
+	}
+	wg.Wait()
+
+	if calls.Load() != 2 {
+		t.Errorf("Expected identical requests to share a call, got %v calls", calls.Load())
+	}
+	if bodies[0] != "1" || bodies[4] != "1" || bodies[5] != "2" {
+		t.Errorf("Unexpected bodies %v", bodies)
+	}
+	if responses[0] == responses[1] {
+		t.Error("Expected every caller to get its own response")
+	}
+}
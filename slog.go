@@ -0,0 +1,46 @@
+package easyrqst
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogLogger adapts a *slog.Logger to the leveled logger WithLogger accepts. Records
+// below its level are dropped, so clients sharing a handler can log at different levels.
+type SlogLogger struct {
+	logger *slog.Logger
+	level  slog.Level
+}
+
+func NewSlogLogger(logger *slog.Logger, level slog.Level) *SlogLogger {
+	return &SlogLogger{logger: logger, level: level}
+}
+
+// WithSlog logs through logger at level and above, with the method, url, status,
+// attempts and elapsed time of every request as structured fields.
+func WithSlog(logger *slog.Logger, level slog.Level) THttpOption {
+	return WithLogger(NewSlogLogger(logger, level))
+}
+
+func (l *SlogLogger) log(level slog.Level, msg string, keysAndValues []interface{}) {
+	if level < l.level {
+		return
+	}
+	l.logger.Log(context.Background(), level, msg, keysAndValues...)
+}
+
+func (l *SlogLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.log(slog.LevelError, msg, keysAndValues)
+}
+
+func (l *SlogLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.log(slog.LevelWarn, msg, keysAndValues)
+}
+
+func (l *SlogLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.log(slog.LevelInfo, msg, keysAndValues)
+}
+
+func (l *SlogLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.log(slog.LevelDebug, msg, keysAndValues)
+}
@@ -0,0 +1,41 @@
+package easyrqst
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSlogLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	if _, err := NewHttpClient(server.URL, WithSlog(logger, slog.LevelInfo)).Get(); err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected debug records to be dropped at info level, got %s", buf.String())
+	}
+
+	if _, err := NewHttpClient(server.URL, WithSlog(logger, slog.LevelDebug)).Get(WithPath("users")); err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	var record map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if strings.Contains(line, "REQUEST_TIME") {
+			json.Unmarshal([]byte(line), &record)
+		}
+	}
+	if record["url"] != "/users" || record["method"] != "GET" || record["status"] != float64(200) || record["attempts"] != float64(1) || record["elapsed"] == nil {
+		t.Errorf("Expected structured request fields, got %v", record)
+	}
+}
@@ -0,0 +1,48 @@
+package easyrqst
+
+import "time"
+
+type StampedeMode int
+
+const (
+	// StampedeNone lets every caller missing the cache go to the origin
+	StampedeNone StampedeMode = iota
+	// StampedeWait sends a single request per missing key, other callers wait for it
+	StampedeWait
+	// StampedeServeStale refreshes an expired entry with a single request while other
+	// callers get the stale entry. Entries are kept for twice their expiry for that.
+	StampedeServeStale
+)
+
+// WithStampedeProtection keeps callers missing the same cache entry at once from all
+// hitting the origin.
+func WithStampedeProtection(mode StampedeMode) THttpOption {
+	return func(o *easyRequest) {
+		o.stampede = mode
+		o.refreshes = &flightGroup{calls: make(map[string]*flightCall)}
+	}
+}
+
+// stale reports whether a cached response outlived its expiry. Only responses kept past
+// their expiry for StampedeServeStale can be stale.
+func (h *easyRequest) stale(response *HttpResponse, expiry time.Duration) bool {
+	if response.maxAge > 0 {
+		expiry = response.maxAge
+	}
+	return h.stampede == StampedeServeStale && expiry > 0 && !response.CachedAt.IsZero() && time.Since(response.CachedAt) > expiry
+}
+
+// storeExpiry is how long responses are kept in the cache.
+func (h *easyRequest) storeExpiry(expiry time.Duration) time.Duration {
+	if h.stampede == StampedeServeStale {
+		return 2 * expiry
+	}
+	return expiry
+}
+
+func (g *flightGroup) inFlight(key string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	_, ok := g.calls[key]
+	return ok
+}
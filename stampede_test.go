@@ -0,0 +1,63 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStampedeProtection(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		time.Sleep(30 * time.Millisecond)
+		w.Write([]byte("report"))
+	}))
+	defer server.Close()
+
+	burst := func(call IHttpClient) (stale int32) {
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				outcome, err := call.Get()
+				if err != nil {
+					t.Errorf("Error: %v", err)
+					return
+				}
+				if outcome.Stale {
+					atomic.AddInt32(&stale, 1)
+				}
+			}()
+		}
+		wg.Wait()
+		return stale
+	}
+
+	cache := WithCache(newMapCache(), 50*time.Millisecond, "v1")
+	burst(NewHttpClient(server.URL, WithDefaults(cache)))
+	if calls.Load() != 5 {
+		t.Errorf("Expected every miss to reach the origin without protection, got %v", calls.Load())
+	}
+
+	calls.Store(0)
+	burst(NewHttpClient(server.URL, WithDefaults(WithCache(newMapCache(), 50*time.Millisecond, "v1")), WithStampedeProtection(StampedeWait)))
+	if calls.Load() != 1 {
+		t.Errorf("Expected waiting callers to share one request, got %v", calls.Load())
+	}
+
+	calls.Store(0)
+	call := NewHttpClient(server.URL, WithDefaults(WithCache(newMapCache(), 50*time.Millisecond, "v1")), WithStampedeProtection(StampedeServeStale))
+	call.Get()
+	time.Sleep(60 * time.Millisecond)
+	if stale := burst(call); stale != 4 || calls.Load() != 2 {
+		t.Errorf("Expected one refresh and 4 stale responses, got %v stale after %v calls", stale, calls.Load())
+	}
+	if outcome, _ := call.Get(); outcome.Stale || !outcome.FromCache {
+		t.Errorf("Expected refreshed entry to be fresh")
+	}
+}
@@ -0,0 +1,63 @@
+package easyrqst
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// HttpError is returned for responses outside the success range set with
+// WithErrorOnStatus. The response is returned along with it.
+type HttpError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	// Err is the body decoded with WithErrorDecoder, or why it couldn't be decoded
+	Err error
+}
+
+func (e *HttpError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s %s: unexpected status code %d: %v", e.Method, e.URL, e.StatusCode, e.Err)
+	}
+	return fmt.Sprintf("%s %s: unexpected status code %d", e.Method, e.URL, e.StatusCode)
+}
+
+func (e *HttpError) Unwrap() error {
+	return e.Err
+}
+
+// WithErrorOnStatus makes Get, Post and Custom return an *HttpError for every response
+// that isn't 2xx, so a failed call can't be mistaken for a successful one. ok lists
+// further statuses that count as success, e.g. http.StatusNotModified.
+func WithErrorOnStatus(ok ...int) THttpOption {
+	return func(o *easyRequest) {
+		o.successStatuses = make(map[int]bool, len(ok))
+		for _, status := range ok {
+			o.successStatuses[status] = true
+		}
+	}
+}
+
+// checkStatus makes the request and turns unexpected statuses into an *HttpError.
+func (h *easyRequest) checkStatus(method string, opts ...TReqOption) (*HttpResponse, error) {
+	response, err := h.do(method, opts...)
+	if err != nil || response == nil || (response.StatusCode >= 200 && response.StatusCode < 300) {
+		return response, err
+	}
+	decode, ok := h.errorDecoder(response.StatusCode)
+	if !ok && (h.successStatuses == nil || h.successStatuses[response.StatusCode]) {
+		return response, nil
+	}
+	httpErr := &HttpError{Method: response.method, StatusCode: response.StatusCode, Header: response.Header, Body: response.Body}
+	if response.url != nil {
+		// Errors end up in logs, keep query credentials out of them
+		options := h.applyOptions(opts...)
+		httpErr.URL = redactURL(response.url, h.secretParams(&options)...)
+	}
+	if decode != nil {
+		httpErr.Err = decodeError(response, decode)
+	}
+	return response, httpErr
+}
@@ -0,0 +1,54 @@
+package easyrqst
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWithErrorOnStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			w.Header().Set("X-Reason", "gone")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("no such item"))
+		case "/cached":
+			w.WriteHeader(http.StatusNotModified)
+		}
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithErrorOnStatus(http.StatusNotModified))
+	response, err := call.Get(WithPath("/missing"))
+	var httpErr *HttpError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("Expected an *HttpError, got %v", err)
+	}
+	if httpErr.StatusCode != http.StatusNotFound || httpErr.Header.Get("X-Reason") != "gone" || string(httpErr.Body) != "no such item" {
+		t.Errorf("Unexpected error contents: %+v", httpErr)
+	}
+	if httpErr.Method != http.MethodGet || httpErr.URL != server.URL+"/missing" {
+		t.Errorf("Expected the request in the error, got %s %s", httpErr.Method, httpErr.URL)
+	}
+	if response == nil || response.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected the response along with the error, got %+v", response)
+	}
+
+	if _, err := call.Custom(http.MethodGet, WithPath("/cached")); err != nil {
+		t.Errorf("Expected 304 to be accepted, got %v", err)
+	}
+	if _, err := call.Post(); err != nil {
+		t.Errorf("Expected no error for a 200, got %v", err)
+	}
+	if _, err := NewHttpClient(server.URL).Get(WithPath("/missing")); err != nil {
+		t.Errorf("Expected no error without the option, got %v", err)
+	}
+
+	_, err = call.Get(WithPath("/missing"), WithAPIKey("api_key", "s3cr3t", InQuery))
+	if err == nil || strings.Contains(err.Error(), "s3cr3t") || !strings.Contains(err.Error(), "/missing") {
+		t.Errorf("Expected the API key to be redacted from the error, got %v", err)
+	}
+}
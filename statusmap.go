@@ -0,0 +1,51 @@
+package easyrqst
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// WithStatusMapping declares what the body of each status decodes into, given as a
+// value of the target type, e.g.
+//
+//	WithStatusMapping(map[int]any{200: (*User)(nil), 404: nil, 409: (*ConflictInfo)(nil)})
+//
+// A nil target means the status carries no value. Exec returns the decoded value.
+func WithStatusMapping(mapping map[int]any) TReqOption {
+	return func(o *ReqOptions) {
+		if o.statuses == nil {
+			o.statuses = make(map[int]reflect.Type)
+		}
+		for status, target := range mapping {
+			o.statuses[status] = reflect.TypeOf(target)
+		}
+	}
+}
+
+// Exec sends the request and decodes the body into the target WithStatusMapping set
+// for the status that arrived, converted to T. Statuses without a mapping are returned
+// as errors along with the response.
+func Exec[T any](client IHttpClient, method string, opts ...TReqOption) (T, *HttpResponse, error) {
+	var result T
+	response, err := client.Custom(method, opts...)
+	if err != nil {
+		return result, response, err
+	}
+	typ, ok := response.statuses[response.StatusCode]
+	if !ok {
+		return result, response, fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+	if typ == nil {
+		return result, response, nil
+	}
+
+	target := reflect.New(typ)
+	if err := response.unmarshal(target.Interface(), typ); err != nil {
+		return result, response, fmt.Errorf("failed to decode status %d into %s: %w", response.StatusCode, typ, err)
+	}
+	value, ok := target.Elem().Interface().(T)
+	if !ok {
+		return result, response, fmt.Errorf("status %d decodes into %s, not %s", response.StatusCode, typ, reflect.TypeOf(&result).Elem())
+	}
+	return value, response, nil
+}
@@ -0,0 +1,64 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type statusUser struct {
+	Name string `json:"name"`
+}
+
+type statusConflict struct {
+	Reason string `json:"reason"`
+}
+
+func TestStatusMapping(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/1":
+			w.Write([]byte(`{"name": "neo"}`))
+		case "/users/2":
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"reason": "locked"}`))
+		case "/users/3":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusTeapot)
+		}
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithDefaults(WithStatusMapping(map[int]any{
+		http.StatusOK:       (*statusUser)(nil),
+		http.StatusNotFound: nil,
+	})))
+	conflict := WithStatusMapping(map[int]any{http.StatusConflict: statusConflict{}})
+
+	for path, check := range map[string]func(any) bool{
+		"users/1": func(v any) bool { user, ok := v.(*statusUser); return ok && user.Name == "neo" },
+		"users/2": func(v any) bool { info, ok := v.(statusConflict); return ok && info.Reason == "locked" },
+		"users/3": func(v any) bool { return v == nil },
+	} {
+		value, _, err := Exec[any](call, http.MethodGet, WithPath(path), conflict)
+		if err != nil {
+			t.Errorf("%s: Error: %v", path, err)
+			continue
+		}
+		if !check(value) {
+			t.Errorf("%s: Unexpected value %#v", path, value)
+		}
+	}
+
+	user, _, err := Exec[*statusUser](call, http.MethodGet, WithPath("users/1"))
+	if err != nil || user.Name != "neo" {
+		t.Errorf("Expected typed user, got %v %v", user, err)
+	}
+	if _, _, err := Exec[*statusUser](call, http.MethodGet, WithPath("users/2"), conflict); err == nil {
+		t.Errorf("Expected error when the mapped type isn't T")
+	}
+	if _, response, err := Exec[any](call, http.MethodGet, WithPath("other")); err == nil || response.StatusCode != http.StatusTeapot {
+		t.Errorf("Expected error with the response for an unmapped status, got %v", err)
+	}
+}
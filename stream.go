@@ -0,0 +1,85 @@
+package easyrqst
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/cipher"
+	"encoding/json"
+	"io"
+)
+
+// TStage transforms a streamed response body, e.g. decompressing or decrypting it.
+type TStage func(io.Reader) (io.Reader, error)
+
+// TSink consumes the transformed body. Data is only read from the network as fast as the
+// sink consumes it.
+type TSink func(io.Reader) error
+
+type streamObj struct {
+	stages []TStage
+	sink   TSink
+}
+
+// WithStream processes a successful response body as it arrives instead of buffering
+// it: the body passes through stages in order and ends in sink. The returned response
+// has no Body and is never cached.
+func WithStream(sink TSink, stages ...TStage) TReqOption {
+	return func(o *ReqOptions) { o.stream = &streamObj{stages: stages, sink: sink} }
+}
+
+func (s *streamObj) run(body io.Reader) error {
+	var err error
+	for _, stage := range s.stages {
+		if body, err = stage(body); err != nil {
+			return err
+		}
+	}
+	return s.sink(body)
+}
+
+func Gunzip() TStage {
+	return func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) }
+}
+
+// Decrypt applies a stream cipher, e.g. AES in CTR mode, to the body.
+func Decrypt(stream cipher.Stream) TStage {
+	return func(r io.Reader) (io.Reader, error) { return &cipher.StreamReader{S: stream, R: r}, nil }
+}
+
+// Lines calls handle for every line of the body, without the line ending. The line is
+// only valid until handle returns.
+func Lines(handle func(line []byte) error) TSink {
+	return func(r io.Reader) error {
+		reader := bufio.NewReader(r)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if len(line) > 0 {
+				if err := handle(bytes.TrimRight(line, "\r\n")); err != nil {
+					return err
+				}
+			}
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// JSONLines decodes a newline delimited JSON body one value at a time. Empty lines are
+// skipped.
+func JSONLines[T any](handle func(T) error) TSink {
+	return Lines(func(line []byte) error {
+		if len(bytes.TrimSpace(line)) == 0 {
+			return nil
+		}
+		var value T
+		if err := json.Unmarshal(line, &value); err != nil {
+			return err
+		}
+		return handle(value)
+	})
+}
@@ -0,0 +1,60 @@
+package easyrqst
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type streamRecord struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestStreamPipeline(t *testing.T) {
+	key, iv := bytes.Repeat([]byte{1}, 16), bytes.Repeat([]byte{2}, 16)
+	newStream := func() cipher.Stream {
+		block, _ := aes.NewCipher(key)
+		return cipher.NewCTR(block, iv)
+	}
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	gz.Write([]byte("{\"id\":1,\"name\":\"neo\"}\n\n{\"id\":2,\"name\":\"trinity\"}\r\n{\"id\":3,\"name\":\"morpheus\"}"))
+	gz.Close()
+	encrypted := make([]byte, compressed.Len())
+	newStream().XORKeyStream(encrypted, compressed.Bytes())
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(encrypted)
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL)
+	var records []streamRecord
+	outcome, err := call.Get(WithStream(JSONLines(func(r streamRecord) error {
+		records = append(records, r)
+		return nil
+	}), Decrypt(newStream()), Gunzip()))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if outcome.Body != nil {
+		t.Errorf("Expected streamed body not to be buffered")
+	}
+	if len(records) != 3 || records[1].Name != "trinity" || records[2].ID != 3 {
+		t.Errorf("Unexpected records %+v", records)
+	}
+
+	stop := errors.New("stop")
+	_, err = call.Get(WithStream(Lines(func([]byte) error { return stop }), Decrypt(newStream()), Gunzip()))
+	if !errors.Is(err, stop) {
+		t.Errorf("Expected sink error, got %v", err)
+	}
+}
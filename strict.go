@@ -0,0 +1,342 @@
+package easyrqst
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// StrictDecodingError lists every mismatch between a response and the type it was
+// decoded into, e.g. `items[2].price: expected int, got string`.
+type StrictDecodingError struct {
+	Problems []string
+}
+
+func (e *StrictDecodingError) Error() string {
+	return "strict decoding: " + strings.Join(e.Problems, "; ")
+}
+
+// WithStrictDecoding makes Decode fail on fields the target type doesn't know and on
+// type mismatches, so contract changes of a provider are noticed instead of ignored.
+func WithStrictDecoding() THttpOption {
+	return func(o *easyRequest) { o.strict = true }
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	xmlUnmarshalerType  = reflect.TypeOf((*xml.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// customDecoding reports whether values of t decode themselves.
+func customDecoding(t reflect.Type, unmarshaler reflect.Type) bool {
+	return t.Implements(unmarshaler) || reflect.PointerTo(t).Implements(unmarshaler) ||
+		t.Implements(textUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+type strictChecker struct {
+	problems []string
+}
+
+func (c *strictChecker) report(path, format string, args ...any) {
+	if path == "" {
+		path = "$"
+	}
+	c.problems = append(c.problems, path+": "+fmt.Sprintf(format, args...))
+}
+
+func (c *strictChecker) err() error {
+	if len(c.problems) == 0 {
+		return nil
+	}
+	return &StrictDecodingError{Problems: c.problems}
+}
+
+func checkStrictJSON(body []byte, t reflect.Type) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return err
+	}
+	c := &strictChecker{}
+	c.json("", value, t)
+	return c.err()
+}
+
+// jsonFields maps the lower-cased JSON names of the fields of t, including promoted
+// fields of embedded structs, to their types.
+func jsonFields(t reflect.Type, fields map[string]reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+		ft := field.Type
+		if field.Anonymous && name == "" {
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				jsonFields(ft, fields)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if strings.Contains(opts, "string") {
+			// Quoted values are checked by encoding/json itself
+			ft = reflect.TypeOf((*any)(nil)).Elem()
+		}
+		fields[strings.ToLower(name)] = ft
+	}
+}
+
+func (c *strictChecker) json(path string, value any, t reflect.Type) {
+	for t.Kind() == reflect.Pointer {
+		if value == nil {
+			return
+		}
+		t = t.Elem()
+	}
+	if value == nil || t.Kind() == reflect.Interface || customDecoding(t, jsonUnmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]any)
+		if !ok {
+			c.report(path, "expected object, got %s", jsonKind(value))
+			return
+		}
+		fields := make(map[string]reflect.Type)
+		jsonFields(t, fields)
+		for key, v := range object {
+			ft, ok := fields[strings.ToLower(key)]
+			if !ok {
+				c.report(joinPath(path, key), "unknown field")
+				continue
+			}
+			c.json(joinPath(path, key), v, ft)
+		}
+	case reflect.Map:
+		object, ok := value.(map[string]any)
+		if !ok {
+			c.report(path, "expected object, got %s", jsonKind(value))
+			return
+		}
+		for key, v := range object {
+			c.json(joinPath(path, key), v, t.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		if _, ok := value.(string); ok && t.Elem().Kind() == reflect.Uint8 {
+			// []byte is base64 encoded
+			return
+		}
+		items, ok := value.([]any)
+		if !ok {
+			c.report(path, "expected array, got %s", jsonKind(value))
+			return
+		}
+		if t.Kind() == reflect.Array && len(items) > t.Len() {
+			c.report(path, "expected at most %d items, got %d", t.Len(), len(items))
+		}
+		for i, v := range items {
+			c.json(fmt.Sprintf("%s[%d]", path, i), v, t.Elem())
+		}
+	case reflect.String:
+		if _, ok := value.(string); !ok {
+			c.report(path, "expected string, got %s", jsonKind(value))
+		}
+	case reflect.Bool:
+		if _, ok := value.(bool); !ok {
+			c.report(path, "expected bool, got %s", jsonKind(value))
+		}
+	default:
+		number, ok := value.(json.Number)
+		if !ok {
+			c.report(path, "expected %s, got %s", t.Kind(), jsonKind(value))
+			return
+		}
+		if err := checkNumber(string(number), t); err != nil {
+			c.report(path, "%v", err)
+		}
+	}
+}
+
+func checkNumber(number string, t reflect.Type) error {
+	var err error
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		_, err = strconv.ParseInt(number, 10, t.Bits())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		_, err = strconv.ParseUint(number, 10, t.Bits())
+	case reflect.Float32, reflect.Float64:
+		_, err = strconv.ParseFloat(number, t.Bits())
+	default:
+		return fmt.Errorf("expected %s, got number", t.Kind())
+	}
+	if err != nil {
+		return fmt.Errorf("%s does not fit %s", number, t.Kind())
+	}
+	return nil
+}
+
+func jsonKind(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case json.Number:
+		return "number"
+	}
+	return "null"
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// xmlNode is a generic XML tree, keeping attributes unlike xmlElement.
+type xmlNode struct {
+	XMLName  xml.Name
+	Attr     []xml.Attr `xml:",any,attr"`
+	Content  string     `xml:",chardata"`
+	Children []xmlNode  `xml:",any"`
+}
+
+func checkStrictXML(body []byte, t reflect.Type) error {
+	var root xmlNode
+	if err := xml.Unmarshal(body, &root); err != nil {
+		return err
+	}
+	c := &strictChecker{}
+	c.xml(root.XMLName.Local, root, t)
+	return c.err()
+}
+
+type xmlFieldSet struct {
+	elements   map[string]reflect.Type
+	attrs      map[string]reflect.Type
+	anyElement bool
+	anyAttr    bool
+}
+
+func xmlFields(t reflect.Type, set *xmlFieldSet) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("xml"), ",")
+		if name == "-" || field.Name == "XMLName" {
+			continue
+		}
+		ft := field.Type
+		if field.Anonymous && name == "" && opts == "" {
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				xmlFields(ft, set)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if _, local, ok := strings.Cut(name, " "); ok {
+			name = local
+		}
+		if first, _, ok := strings.Cut(name, ">"); ok {
+			// a>b paths are matched on their first element only
+			name, ft = first, reflect.TypeOf((*any)(nil)).Elem()
+		}
+		if name == "" {
+			name = field.Name
+		}
+		switch {
+		case strings.Contains(opts, "attr"):
+			if strings.Contains(opts, "any") {
+				set.anyAttr = true
+			} else {
+				set.attrs[name] = ft
+			}
+		case strings.Contains(opts, "innerxml"), strings.Contains(opts, "any"):
+			set.anyElement = true
+		case strings.Contains(opts, "chardata"), strings.Contains(opts, "cdata"), strings.Contains(opts, "comment"):
+		default:
+			set.elements[name] = ft
+		}
+	}
+}
+
+func (c *strictChecker) xml(path string, element xmlNode, t reflect.Type) {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8 {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Interface || customDecoding(t, xmlUnmarshalerType) {
+		return
+	}
+
+	if t.Kind() != reflect.Struct {
+		content := strings.TrimSpace(element.Content)
+		switch t.Kind() {
+		case reflect.String, reflect.Slice:
+		case reflect.Bool:
+			if _, err := strconv.ParseBool(content); err != nil && content != "" {
+				c.report(path, "expected bool, got %q", content)
+			}
+		default:
+			if content != "" {
+				if err := checkNumber(content, t); err != nil {
+					c.report(path, "%v", err)
+				}
+			}
+		}
+		if len(element.Children) > 0 {
+			c.report(path, "expected %s, got element", t.Kind())
+		}
+		return
+	}
+
+	set := &xmlFieldSet{elements: make(map[string]reflect.Type), attrs: make(map[string]reflect.Type)}
+	xmlFields(t, set)
+	for _, attr := range element.Attr {
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" || set.anyAttr {
+			continue
+		}
+		ft, ok := set.attrs[attr.Name.Local]
+		if !ok {
+			c.report(path+"@"+attr.Name.Local, "unknown attribute")
+			continue
+		}
+		c.xml(path+"@"+attr.Name.Local, xmlNode{Content: attr.Value}, ft)
+	}
+	for _, child := range element.Children {
+		ft, ok := set.elements[child.XMLName.Local]
+		if !ok {
+			if !set.anyElement {
+				c.report(joinPath(path, child.XMLName.Local), "unknown element")
+			}
+			continue
+		}
+		c.xml(joinPath(path, child.XMLName.Local), child, ft)
+	}
+}
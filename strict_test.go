@@ -0,0 +1,91 @@
+package easyrqst
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type strictBase struct {
+	ID int `json:"id"`
+}
+
+type strictItem struct {
+	Name  string `json:"name"`
+	Price int    `json:"price"`
+}
+
+type strictOrder struct {
+	strictBase
+	Items  []strictItem `json:"items"`
+	Paid   bool         `json:"paid"`
+	Custom any          `json:"custom"`
+}
+
+type strictFeed struct {
+	Version string `xml:"version,attr"`
+	Title   string `xml:"title"`
+	Count   int    `xml:"count"`
+}
+
+func TestStrictDecoding(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/order":
+			w.Write([]byte(`{"id":1,"items":[{"name":"a","price":1},{"name":"b","price":"2","sku":"x"}],"paid":true,"custom":{"any":1},"status":"new"}`))
+		case "/feed":
+			w.Header().Set("Content-Type", "application/xml")
+			w.Write([]byte(`<feed version="2" lang="en"><title>news</title><count>many</count><author/></feed>`))
+		}
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL)
+	outcome, err := call.Get(WithPath("/order"))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	var strictErr *StrictDecodingError
+	if _, err := Decode[strictOrder](outcome); err == nil || errors.As(err, &strictErr) {
+		t.Errorf("Expected only the standard type error without strict mode, got %v", err)
+	}
+
+	strict := NewHttpClient(server.URL, WithStrictDecoding())
+	outcome, err = strict.Get(WithPath("/order"))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	_, err = Decode[strictOrder](outcome)
+	if !errors.As(err, &strictErr) {
+		t.Errorf("Expected *StrictDecodingError, got %v", err)
+		return
+	}
+	for _, expected := range []string{"items[1].price: expected int, got string", "items[1].sku: unknown field", "status: unknown field"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected %q in %v", expected, err)
+		}
+	}
+	if len(strictErr.Problems) != 3 {
+		t.Errorf("Expected 3 problems, got %v", strictErr.Problems)
+	}
+
+	outcome, err = strict.Get(WithPath("/feed"))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	_, err = Decode[strictFeed](outcome)
+	if !errors.As(err, &strictErr) || len(strictErr.Problems) != 3 {
+		t.Errorf("Expected 3 XML problems, got %v", err)
+		return
+	}
+	for _, expected := range []string{"feed@lang: unknown attribute", "feed.count: many does not fit int", "feed.author: unknown element"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected %q in %v", expected, err)
+		}
+	}
+}
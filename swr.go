@@ -0,0 +1,53 @@
+package easyrqst
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// WithStaleWhileRevalidate serves an expired cache entry right away, with Stale set,
+// for up to window past its expiry while a single background request refreshes it.
+// The refresh revalidates the entry when WithRevalidation allows it.
+func WithStaleWhileRevalidate(window time.Duration) THttpOption {
+	return func(o *easyRequest) {
+		o.staleWindow = window
+		if o.refreshes == nil {
+			o.refreshes = &flightGroup{calls: make(map[string]*flightCall)}
+		}
+	}
+}
+
+// expired reports whether a cached response outlived its freshness lifetime.
+func (h *easyRequest) expired(response *HttpResponse, expiry time.Duration) bool {
+	if response.maxAge > 0 {
+		expiry = response.maxAge
+	}
+	return expiry > 0 && !response.CachedAt.IsZero() && time.Since(response.CachedAt) > expiry
+}
+
+// servableStale reports whether an expired cached response is still within the stale
+// window.
+func (h *easyRequest) servableStale(response *HttpResponse, expiry time.Duration) bool {
+	if response.maxAge > 0 {
+		expiry = response.maxAge
+	}
+	return h.staleWindow > 0 && h.expired(response, expiry) && time.Since(response.CachedAt) <= expiry+h.staleWindow
+}
+
+// refreshInBackground refreshes a cached entry after the caller got the stale one. The
+// refresh outlives the caller's context and has its own retry state.
+func (h *easyRequest) refreshInBackground(key string, req *http.Request, options *ReqOptions, cached *HttpResponse) {
+	if h.refreshes.inFlight(key) {
+		return
+	}
+	refresh := withState(req.Clone(context.WithoutCancel(req.Context())), options)
+	entry := *cached
+	entry.Header = cached.Header.Clone()
+	go h.refreshes.do(key, func() (*HttpResponse, error) {
+		if h.revalidatable(&entry, options.cacheObj.expiry) {
+			return h.revalidate(refresh, options, &entry)
+		}
+		return h.fetch(refresh, options)
+	})
+}
@@ -0,0 +1,73 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStaleWhileRevalidate(t *testing.T) {
+	var version atomic.Int32
+	version.Store(1)
+	var calls atomic.Int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) > 1 {
+			<-release
+		}
+		w.Write([]byte{byte('0' + version.Load())})
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithStaleWhileRevalidate(time.Minute), WithDefaults(WithCache(newMapCache(), 20*time.Millisecond, "")))
+	call.Get()
+	time.Sleep(30 * time.Millisecond)
+	version.Store(2)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		resp, err := call.Get()
+		if err != nil {
+			t.Errorf("Error: %v", err)
+			return
+		}
+		if !resp.FromCache || !resp.Stale || string(resp.Body) != "1" {
+			t.Errorf("Expected the stale entry served, got %v %v %q", resp.FromCache, resp.Stale, resp.Body)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("Expected stale entries without waiting on the refresh, took %v", elapsed)
+	}
+	close(release)
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if resp, _ := call.Get(); !resp.Stale && string(resp.Body) == "2" {
+			if calls.Load() != 2 {
+				t.Errorf("Expected a single background refresh, got %d calls", calls.Load())
+			}
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Errorf("Expected the background refresh to update the entry")
+}
+
+func TestStaleWhileRevalidateWindow(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	cache := &expiryCache{mapCache: newMapCache(), expiries: make(map[string]time.Duration)}
+	call := NewHttpClient(server.URL, WithStaleWhileRevalidate(time.Minute), WithDefaults(WithCache(cache, time.Second, "")))
+	call.Get()
+	if expiry := cache.expiries["GET__?"]; expiry != time.Minute+time.Second {
+		t.Errorf("Expected the entry kept for the stale window, got %v", expiry)
+	}
+
+	entry := &HttpResponse{CachedAt: time.Now().Add(-2 * time.Minute)}
+	if call.(*easyRequest).servableStale(entry, time.Second) {
+		t.Errorf("Expected an entry past the window not to be served stale")
+	}
+}
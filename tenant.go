@@ -0,0 +1,93 @@
+package easyrqst
+
+import (
+	"github.com/hashicorp/go-cleanhttp"
+	"net/http"
+	"sync"
+)
+
+type ITenantClient interface {
+	For(tenant string) IHttpClient
+	Tenants() []string
+}
+
+// TTenantOptions returns the client options specific to a tenant, e.g. its own
+// credentials, cache or limits.
+type TTenantOptions func(tenant string) []THttpOption
+
+type tenantClient struct {
+	mu        sync.Mutex
+	endpoint  string
+	transport *http.Transport
+	opts      []THttpOption
+	perTenant TTenantOptions
+	clients   map[string]IHttpClient
+}
+
+// NewTenantHttpClient partitions a client per tenant. Every tenant gets its own
+// client, so client-level state such as limits and cache keys never leaks between
+// tenants, while connections are pooled on a single shared transport. Tenants with
+// TLS, proxy or other transport options get their own copy of the transport.
+func NewTenantHttpClient(endpoint string, perTenant TTenantOptions, opts ...THttpOption) ITenantClient {
+	return &tenantClient{
+		endpoint:  endpoint,
+		transport: cleanhttp.DefaultPooledTransport(),
+		opts:      opts,
+		perTenant: perTenant,
+		clients:   make(map[string]IHttpClient),
+	}
+}
+
+func WithCacheNamespace(namespace string) THttpOption {
+	return func(o *easyRequest) { o.namespace = namespace }
+}
+
+// withSharedTransport uses transport until an option changes it, which then gets a
+// copy of its own so the change stays with this client.
+func withSharedTransport(transport *http.Transport) THttpOption {
+	return func(o *easyRequest) {
+		o.transport = transport
+		o.sharedTransport = true
+	}
+}
+
+// ownTransport returns the transport of the client for options to change.
+func (h *easyRequest) ownTransport() *http.Transport {
+	if h.sharedTransport {
+		// Clone copies TLSClientConfig as well
+		h.transport = h.transport.Clone()
+		h.sharedTransport = false
+	}
+	return h.transport
+}
+
+func (t *tenantClient) For(tenant string) IHttpClient {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if client, ok := t.clients[tenant]; ok {
+		return client
+	}
+
+	opts := []THttpOption{withSharedTransport(t.transport)}
+	opts = append(opts, t.opts...)
+	if t.perTenant != nil {
+		opts = append(opts, t.perTenant(tenant)...)
+	}
+	// Tenants must not read each other's entries, whatever the options say
+	opts = append(opts, WithCacheNamespace(tenant))
+	client := NewHttpClient(t.endpoint, opts...)
+	t.clients[tenant] = client
+	return client
+}
+
+func (t *tenantClient) Tenants() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tenants := make([]string, 0, len(t.clients))
+	for tenant := range t.clients {
+		tenants = append(tenants, tenant)
+	}
+	return tenants
+}
@@ -0,0 +1,116 @@
+package easyrqst
+
+import (
+	"crypto/tls"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type mapCache struct {
+	mu    sync.Mutex
+	items map[string]any
+}
+
+func newMapCache() *mapCache {
+	return &mapCache{items: make(map[string]any)}
+}
+
+func (m *mapCache) Get(key string) (any, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if v, ok := m.items[key]; ok {
+		return v, nil
+	}
+	return nil, errors.New("key not found")
+}
+
+func (m *mapCache) Set(key string, value any, expiry time.Duration) (any, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.items[key] = value
+	return nil, nil
+}
+
+func (m *mapCache) Delete(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.items, key)
+	return nil
+}
+
+func TestTenantClientIsolation(t *testing.T) {
+	server := newEchoAuthServer()
+	defer server.Close()
+
+	cache := newMapCache()
+	tenants := NewTenantHttpClient(server.URL, func(tenant string) []THttpOption {
+		return []THttpOption{WithClientBasicAuth(tenant, "secret")}
+	})
+
+	acme := tenants.For("acme")
+	if acme != tenants.For("acme") {
+		t.Errorf("Expected the same client for the same tenant")
+	}
+
+	first, err := acme.Get(WithCache(cache, time.Minute, "auth"))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	second, err := tenants.For("globex").Get(WithCache(cache, time.Minute, "auth"))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if second.FromCache {
+		t.Errorf("Expected tenant caches to be isolated")
+	}
+	if string(first.Body) == string(second.Body) {
+		t.Errorf("Expected tenant specific credentials, got %s twice", first.Body)
+	}
+	if !strings.HasPrefix(second.CacheKey(), "globex_") {
+		t.Errorf("Expected cache key namespaced by tenant, got %s", second.CacheKey())
+	}
+	if len(tenants.Tenants()) != 2 {
+		t.Errorf("Expected 2 tenants, got %v", tenants.Tenants())
+	}
+}
+
+func (m *mapCache) keys() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	keys := make([]string, 0, len(m.items))
+	for k := range m.items {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func TestTenantTransportOptions(t *testing.T) {
+	tenants := NewTenantHttpClient("http://api.test", func(tenant string) []THttpOption {
+		opts := []THttpOption{WithCacheNamespace("shared")}
+		if tenant == "acme" {
+			opts = append(opts, WithTLSCertificates(tls.Certificate{Certificate: [][]byte{[]byte(tenant)}}))
+		}
+		return opts
+	})
+	shared := tenants.(*tenantClient).transport
+	acme := tenants.For("acme").(*easyRequest)
+	globex := tenants.For("globex").(*easyRequest)
+
+	if acme.transport == shared || len(acme.transport.TLSClientConfig.Certificates) != 1 {
+		t.Errorf("Expected the tenant certificate on a transport of its own")
+	}
+	if shared.TLSClientConfig != nil && len(shared.TLSClientConfig.Certificates) != 0 {
+		t.Errorf("Expected the shared transport to be left alone, got %v", shared.TLSClientConfig.Certificates)
+	}
+	if globex.transport != shared {
+		t.Errorf("Expected tenants without transport options to share the transport")
+	}
+	if acme.namespace != "acme" || globex.namespace != "globex" {
+		t.Errorf("Expected tenant namespaces to win, got %s and %s", acme.namespace, globex.namespace)
+	}
+}
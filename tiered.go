@@ -0,0 +1,113 @@
+package easyrqst
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+	"time"
+)
+
+type tieredEntry struct {
+	key     string
+	value   any
+	expires time.Time
+}
+
+// TieredCache keeps the most recently used entries of a remote cache, like Redis, in
+// process. Reads check the memory tier first and back-fill it from the remote one;
+// writes and deletes go to both.
+type TieredCache struct {
+	remote  ICacheFn
+	maxTTL  time.Duration
+	mu      sync.Mutex
+	max     int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+// NewTieredCache keeps up to maxEntries entries of remote in memory, for at most maxTTL
+// and never past the expiry they were stored with. Entries read from the remote tier
+// don't say how long they have left, so maxTTL is required: it bounds how long a
+// process may serve an entry that expired or changed in the remote tier.
+func NewTieredCache(remote ICacheFn, maxEntries int, maxTTL time.Duration) (*TieredCache, error) {
+	if maxTTL <= 0 {
+		return nil, errors.New("tiered cache needs a positive maxTTL")
+	}
+	return &TieredCache{remote: remote, maxTTL: maxTTL, max: maxEntries, order: list.New(), entries: make(map[string]*list.Element)}, nil
+}
+
+func (c *TieredCache) Get(key string) (any, error) {
+	if value, ok := c.local(key); ok {
+		return value, nil
+	}
+	value, err := c.remote.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	c.remember(key, value, c.maxTTL)
+	return value, nil
+}
+
+func (c *TieredCache) Set(key string, value any, expiry time.Duration) (any, error) {
+	result, err := c.remote.Set(key, value, expiry)
+	if err != nil {
+		c.forget(key)
+		return result, err
+	}
+	if expiry <= 0 || expiry > c.maxTTL {
+		expiry = c.maxTTL
+	}
+	c.remember(key, value, expiry)
+	return result, nil
+}
+
+func (c *TieredCache) Delete(key string) error {
+	c.forget(key)
+	return c.remote.Delete(key)
+}
+
+func (c *TieredCache) local(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := el.Value.(*tieredEntry)
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		c.order.Remove(el)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return entry.value, true
+}
+
+func (c *TieredCache) remember(key string, value any, expiry time.Duration) {
+	entry := &tieredEntry{key: key, value: value}
+	if expiry > 0 {
+		entry.expires = time.Now().Add(expiry)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		c.order.Remove(el)
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	for c.max > 0 && c.order.Len() > c.max {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*tieredEntry).key)
+	}
+}
+
+func (c *TieredCache) forget(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		c.order.Remove(el)
+		delete(c.entries, key)
+	}
+}
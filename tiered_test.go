@@ -0,0 +1,102 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type countingCache struct {
+	*mapCache
+	gets int
+}
+
+func (c *countingCache) Get(key string) (any, error) {
+	c.gets++
+	return c.mapCache.Get(key)
+}
+
+func TestTieredCache(t *testing.T) {
+	remote := &countingCache{mapCache: newMapCache()}
+	remote.Set("a", "1", 0)
+	remote.Set("b", "2", 0)
+	remote.Set("c", "3", 0)
+
+	cache, err := NewTieredCache(remote, 2, time.Minute)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	for _, key := range []string{"a", "a", "b", "a", "c", "b"} {
+		if _, err := cache.Get(key); err != nil {
+			t.Errorf("Error: %v", err)
+		}
+	}
+	// a, b and c are back-filled; c evicts b, the least recently used
+	if remote.gets != 4 {
+		t.Errorf("Expected 4 remote reads, got %d", remote.gets)
+	}
+
+	cache.Set("d", "4", time.Hour)
+	if value, _ := remote.mapCache.Get("d"); value != "4" {
+		t.Errorf("Expected writes to reach the remote tier")
+	}
+	if value, _ := cache.Get("d"); value != "4" || remote.gets != 4 {
+		t.Errorf("Expected writes to fill the memory tier, got %v after %d remote reads", value, remote.gets)
+	}
+
+	cache.Delete("d")
+	if _, err := cache.Get("d"); err == nil {
+		t.Errorf("Expected deletes to clear both tiers")
+	}
+
+	if _, err := cache.Get("missing"); err == nil {
+		t.Errorf("Expected remote misses to be reported")
+	}
+}
+
+func TestTieredCacheMaxTTL(t *testing.T) {
+	remote := &countingCache{mapCache: newMapCache()}
+	cache, _ := NewTieredCache(remote, 10, 10*time.Millisecond)
+	cache.Set("a", "1", time.Hour)
+	remote.mapCache.Set("a", "2", time.Hour)
+
+	if value, _ := cache.Get("a"); value != "1" {
+		t.Errorf("Expected the memory tier to answer, got %v", value)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if value, _ := cache.Get("a"); value != "2" {
+		t.Errorf("Expected the remote tier after the memory TTL, got %v", value)
+	}
+
+	remote.mapCache.Delete("a")
+	time.Sleep(20 * time.Millisecond)
+	if _, err := cache.Get("a"); err == nil {
+		t.Errorf("Expected back-filled entries to expire with maxTTL")
+	}
+	if _, err := NewTieredCache(remote, 10, 0); err == nil {
+		t.Errorf("Expected a zero maxTTL to be rejected")
+	}
+}
+
+func TestTieredCacheWithClient(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte("hot"))
+	}))
+	defer server.Close()
+
+	remote := &countingCache{mapCache: newMapCache()}
+	cache, _ := NewTieredCache(remote, 100, time.Minute)
+	call := NewHttpClient(server.URL, WithDefaults(WithCache(cache, time.Minute, "")))
+	for i := 0; i < 3; i++ {
+		resp, err := call.Get()
+		if err != nil || string(resp.Body) != "hot" {
+			t.Errorf("Expected the response, got %v", err)
+		}
+	}
+	if calls != 1 || remote.gets != 1 {
+		t.Errorf("Expected hits from memory, got %d calls and %d remote reads", calls, remote.gets)
+	}
+}
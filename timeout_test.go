@@ -0,0 +1,29 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTimeouts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	if _, err := NewHttpClient(server.URL, WithClientTimeout(time.Millisecond*50)).Get(); err == nil {
+		t.Errorf("Expected client timeout")
+	}
+	if _, err := NewHttpClient(server.URL).Get(WithTimeout(time.Millisecond * 50)); err == nil {
+		t.Errorf("Expected request timeout")
+	}
+	if elapsed := time.Since(start); elapsed > time.Millisecond*900 {
+		t.Errorf("Expected timeouts to cut requests short, took %v", elapsed)
+	}
+}
@@ -0,0 +1,63 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptrace"
+	"time"
+)
+
+// Timings breaks down where the last attempt of a request spent its time. Phases that
+// didn't happen, like DNS and connecting on a reused connection, are zero. Responses
+// served from the cache have no timings.
+type Timings struct {
+	DNSLookup    time.Duration
+	TCPConnect   time.Duration
+	TLSHandshake time.Duration
+	// TimeToFirstByte runs from getting a connection to the first response byte, so it
+	// includes the phases above
+	TimeToFirstByte time.Duration
+	Transfer        time.Duration
+	Total           time.Duration
+	ConnReused      bool
+}
+
+func between(from, to time.Time) time.Duration {
+	if from.IsZero() || to.IsZero() {
+		return 0
+	}
+	return to.Sub(from)
+}
+
+func (t *traceTimings) timings(end time.Time, reused bool) Timings {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return Timings{
+		DNSLookup:       between(t.dnsStart, t.dnsDone),
+		TCPConnect:      between(t.connectStart, t.connDone),
+		TLSHandshake:    between(t.tlsStart, t.tlsDone),
+		TimeToFirstByte: between(t.attemptStart, t.firstByte),
+		Transfer:        between(t.firstByte, end),
+		Total:           between(t.attemptStart, end),
+		ConnReused:      reused,
+	}
+}
+
+// withTimings measures req for HttpResponse.Timings. The returned function is called
+// once the body is read.
+func withTimings(req *http.Request) (*http.Request, func() Timings) {
+	timings := &traceTimings{start: time.Now()}
+	var reused bool
+	trace := timings.trace()
+	trace.GotConn = func(info httptrace.GotConnInfo) {
+		timings.mu.Lock()
+		defer timings.mu.Unlock()
+		reused = info.Reused
+	}
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+	return req, func() Timings {
+		timings.mu.Lock()
+		wasReused := reused
+		timings.mu.Unlock()
+		return timings.timings(time.Now(), wasReused)
+	}
+}
@@ -0,0 +1,62 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTimings(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	transport := server.Client().Transport.(*http.Transport).Clone()
+	call := NewHttpClient(server.URL, WithTransport(transport))
+	resp, err := call.Get()
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	timings := resp.Timings
+	if timings.TCPConnect <= 0 || timings.TLSHandshake <= 0 || timings.ConnReused {
+		t.Errorf("Expected connect and TLS timings on a new connection, got %+v", timings)
+	}
+	if timings.TimeToFirstByte < 20*time.Millisecond || timings.Total < timings.TimeToFirstByte {
+		t.Errorf("Expected the server delay in the time to first byte, got %+v", timings)
+	}
+
+	resp, err = call.Get()
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if timings := resp.Timings; !timings.ConnReused || timings.TCPConnect != 0 || timings.TLSHandshake != 0 {
+		t.Errorf("Expected no connect phases on a reused connection, got %+v", timings)
+	}
+}
+
+func TestTimingsLastAttempt(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			time.Sleep(50 * time.Millisecond)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithRetryWaitMax(time.Millisecond))
+	resp, err := call.Get()
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if resp.Timings.TimeToFirstByte >= 50*time.Millisecond {
+		t.Errorf("Expected timings of the last attempt only, got %+v", resp.Timings)
+	}
+}
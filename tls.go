@@ -0,0 +1,102 @@
+package easyrqst
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+)
+
+type TLSPolicy int
+
+const (
+	// TLSModern only allows TLS 1.3.
+	TLSModern TLSPolicy = iota
+	// TLSIntermediate allows TLS 1.2 with forward secret AEAD cipher suites and TLS 1.3.
+	TLSIntermediate
+	// TLSLegacy allows TLS 1.0 and older cipher suites for servers that can't be upgraded.
+	TLSLegacy
+)
+
+var intermediateCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256,
+}
+
+var legacyCipherSuites = append(append([]uint16{}, intermediateCipherSuites...),
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA,
+	tls.TLS_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_RSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_RSA_WITH_AES_128_CBC_SHA256,
+	tls.TLS_RSA_WITH_AES_128_CBC_SHA,
+	tls.TLS_RSA_WITH_AES_256_CBC_SHA,
+	tls.TLS_RSA_WITH_3DES_EDE_CBC_SHA,
+)
+
+func (h *easyRequest) tlsConfig() *tls.Config {
+	transport := h.ownTransport()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	return transport.TLSClientConfig
+}
+
+func WithTLSPolicy(policy TLSPolicy) THttpOption {
+	return func(o *easyRequest) {
+		cfg := o.tlsConfig()
+		cfg.CurvePreferences = []tls.CurveID{tls.X25519, tls.CurveP256, tls.CurveP384}
+		switch policy {
+		case TLSModern:
+			cfg.MinVersion = tls.VersionTLS13
+			cfg.CipherSuites = nil
+		case TLSIntermediate:
+			cfg.MinVersion = tls.VersionTLS12
+			cfg.CipherSuites = intermediateCipherSuites
+		case TLSLegacy:
+			cfg.MinVersion = tls.VersionTLS10
+			cfg.CipherSuites = legacyCipherSuites
+		}
+	}
+}
+
+func WithTLSCertificates(certs ...tls.Certificate) THttpOption {
+	return func(o *easyRequest) {
+		cfg := o.tlsConfig()
+		cfg.Certificates = append(cfg.Certificates, certs...)
+	}
+}
+
+// WithClientCertificate loads a PEM encoded certificate and key for mutual TLS. A load
+// failure is returned by every request made with the client.
+func WithClientCertificate(certFile, keyFile string) THttpOption {
+	return func(o *easyRequest) {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			o.initErr = fmt.Errorf("failed to load client certificate: %w", err)
+			return
+		}
+		WithTLSCertificates(cert)(o)
+	}
+}
+
+func WithRootCAs(pool *x509.CertPool) THttpOption {
+	return func(o *easyRequest) { o.tlsConfig().RootCAs = pool }
+}
+
+func WithTLSMinVersion(version uint16) THttpOption {
+	return func(o *easyRequest) { o.tlsConfig().MinVersion = version }
+}
+
+// WithInsecureSkipVerify disables server certificate verification. Only meant for
+// development servers with self-signed certificates.
+func WithInsecureSkipVerify(skip bool) THttpOption {
+	return func(o *easyRequest) { o.tlsConfig().InsecureSkipVerify = skip }
+}
@@ -0,0 +1,112 @@
+package easyrqst
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTLSPolicy(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+
+	transport := server.Client().Transport.(*http.Transport)
+
+	call := NewHttpClient(server.URL, WithTransport(transport.Clone()), WithTLSPolicy(TLSIntermediate), WithRetry(0))
+	if _, err := call.Get(); err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	call = NewHttpClient(server.URL, WithTransport(transport.Clone()), WithTLSPolicy(TLSModern), WithRetry(0))
+	if _, err := call.Get(); err == nil {
+		t.Errorf("Expected handshake to fail against a TLS 1.2 server")
+	}
+}
+
+func writeTestCertificate(t *testing.T) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "easyrqst"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600)
+	return certFile, keyFile
+}
+
+func TestClientCertificate(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	transport := server.Client().Transport.(*http.Transport)
+	certFile, keyFile := writeTestCertificate(t)
+
+	call := NewHttpClient(server.URL, WithTransport(transport.Clone()), WithClientCertificate(certFile, keyFile))
+	outcome, err := call.Get()
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if string(outcome.Body) != "easyrqst" {
+		t.Errorf("Expected client certificate to be presented, got %s", outcome.Body)
+	}
+
+	call = NewHttpClient(server.URL, WithClientCertificate("missing.pem", "missing.key"))
+	if _, err := call.Get(); err == nil {
+		t.Errorf("Expected error for missing certificate files")
+	}
+}
+
+func TestRootCAsAndSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	if _, err := NewHttpClient(server.URL, WithRetry(0)).Get(); err == nil {
+		t.Errorf("Expected untrusted certificate error")
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	if _, err := NewHttpClient(server.URL, WithRootCAs(pool), WithTLSMinVersion(tls.VersionTLS12)).Get(); err != nil {
+		t.Errorf("Error: %v", err)
+	}
+
+	if _, err := NewHttpClient(server.URL, WithInsecureSkipVerify(true)).Get(); err != nil {
+		t.Errorf("Error: %v", err)
+	}
+}
@@ -0,0 +1,198 @@
+package easyrqst
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/go-retryablehttp"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// DefaultRedactedFields are the body fields WithTrafficLogging always masks.
+var DefaultRedactedFields = []string{"password", "token", "access_token", "refresh_token", "secret", "client_secret"}
+
+// RedactionRules tell WithTrafficLogging what to mask.
+type RedactionRules struct {
+	// Headers whose name contains any of these, on top of Authorization, Cookie and
+	// other usual credential headers.
+	Headers []string
+	// Fields of JSON and form bodies, at any depth, on top of DefaultRedactedFields.
+	Fields []string
+	// MaxBody is the number of body bytes logged, 2048 by default.
+	MaxBody int
+}
+
+// WithTrafficLogging logs the headers and the start of the body of every request and
+// its response at debug level, with credentials masked according to rules. Meant for
+// debugging in production, it follows WithLogSampling.
+func WithTrafficLogging(rules RedactionRules) THttpOption {
+	if rules.MaxBody <= 0 {
+		rules.MaxBody = 2048
+	}
+	rules.Fields = append(append([]string{}, DefaultRedactedFields...), rules.Fields...)
+	return func(o *easyRequest) { o.traffic = &rules }
+}
+
+// logTraffic captures req before it is sent; the returned function logs it along with
+// its response.
+func (h *easyRequest) logTraffic(req *http.Request, options *ReqOptions) func(statusCode int, header http.Header, body []byte, err error) {
+	rules := h.traffic
+	if rules == nil || h.logger == nil {
+		return func(int, http.Header, []byte, error) {}
+	}
+
+	var sent []byte
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			sent, _ = io.ReadAll(body)
+			body.Close()
+		}
+	}
+	redacted := redactURL(req.URL, h.secretParams(options)...)
+	secret := append(h.secretHeaders(options), rules.Headers...)
+	request := fmt.Sprintf("%s %s %v %s", req.Method, redacted, redactHeader(req.Header, secret...), rules.body(req.Header, sent))
+
+	return func(statusCode int, header http.Header, body []byte, err error) {
+		if !h.sampling.keep(statusCode, err) {
+			return
+		}
+		response := fmt.Sprintf("%d %v %s", statusCode, redactHeader(header, secret...), rules.body(header, body))
+		if err != nil {
+			// Transport errors quote the URL
+			response = strings.ReplaceAll(err.Error(), req.URL.String(), redacted)
+		}
+		switch v := h.logger.(type) {
+		case retryablehttp.LeveledLogger:
+			v.Debug("HTTP_TRAFFIC", "request", request, "response", response)
+		case retryablehttp.Logger:
+			v.Printf("HTTP_TRAFFIC request=%q response=%q", request, response)
+		}
+	}
+}
+
+// body masks the sensitive fields of a JSON or form body and truncates it.
+func (r *RedactionRules) body(header http.Header, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	contentType := header.Get("Content-Type")
+	switch {
+	case strings.Contains(contentType, "json"):
+		var node any
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		if decoder.Decode(&node) == nil {
+			if masked, err := marshalJSON(r.redactJSON(node)); err == nil {
+				body = masked
+			}
+		}
+	case contentType == "application/x-www-form-urlencoded":
+		if values, err := url.ParseQuery(string(body)); err == nil {
+			for name := range values {
+				if r.sensitiveField(name) {
+					values[name] = []string{"REDACTED"}
+				}
+			}
+			body = []byte(values.Encode())
+		}
+	}
+
+	if len(body) > r.MaxBody {
+		return fmt.Sprintf("%s... (%d bytes)", body[:r.MaxBody], len(body))
+	}
+	return string(body)
+}
+
+func (r *RedactionRules) redactJSON(node any) any {
+	switch v := node.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if r.sensitiveField(key) {
+				v[key] = "REDACTED"
+			} else {
+				v[key] = r.redactJSON(value)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = r.redactJSON(item)
+		}
+	}
+	return node
+}
+
+func (r *RedactionRules) sensitiveField(name string) bool {
+	for _, field := range r.Fields {
+		if strings.EqualFold(name, field) {
+			return true
+		}
+	}
+	return false
+}
+
+var queryValue = regexp.MustCompile(`([?&][^=&\s"]+=)[^&\s"]*`)
+
+// maskQueries masks every query parameter value of the URLs in s.
+func maskQueries(s string) string {
+	return queryValue.ReplaceAllString(s, "${1}REDACTED")
+}
+
+// queryMaskingLogger masks query parameters in what retryablehttp logs about requests,
+// as it only knows to hide passwords in URLs.
+type queryMaskingLogger struct {
+	logger retryablehttp.Logger
+}
+
+func (l queryMaskingLogger) Printf(format string, args ...interface{}) {
+	l.logger.Printf("%s", maskQueries(fmt.Sprintf(format, args...)))
+}
+
+type leveledQueryMaskingLogger struct {
+	logger retryablehttp.LeveledLogger
+}
+
+func (l leveledQueryMaskingLogger) mask(keysAndValues []interface{}) []interface{} {
+	masked := make([]interface{}, len(keysAndValues))
+	for i, v := range keysAndValues {
+		switch v := v.(type) {
+		case string:
+			masked[i] = maskQueries(v)
+		case error:
+			masked[i] = maskQueries(v.Error())
+		default:
+			masked[i] = v
+		}
+	}
+	return masked
+}
+
+func (l leveledQueryMaskingLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.logger.Error(msg, l.mask(keysAndValues)...)
+}
+
+func (l leveledQueryMaskingLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.logger.Info(msg, l.mask(keysAndValues)...)
+}
+
+func (l leveledQueryMaskingLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.logger.Debug(msg, l.mask(keysAndValues)...)
+}
+
+func (l leveledQueryMaskingLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.logger.Warn(msg, l.mask(keysAndValues)...)
+}
+
+// maskingQueries wraps logger for retryablehttp so it doesn't log query parameters.
+func maskingQueries(logger interface{}) interface{} {
+	switch v := logger.(type) {
+	case retryablehttp.LeveledLogger:
+		return leveledQueryMaskingLogger{logger: v}
+	case retryablehttp.Logger:
+		return queryMaskingLogger{logger: v}
+	}
+	return logger
+}
@@ -0,0 +1,48 @@
+package easyrqst
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTrafficLogging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=s3cr3t")
+		w.Write([]byte(`{"access_token": "t0k3n", "user": {"name": "neo"}, "padding": "` + strings.Repeat("x", 200) + `"}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	call := NewHttpClient(server.URL, WithLogger(log.New(&buf, "", 0)), WithTrafficLogging(RedactionRules{
+		Headers: []string{"X-Internal"},
+		Fields:  []string{"pin"},
+		MaxBody: 100,
+	}))
+	_, err := call.Post(
+		WithAPIKey("k", "qk3y", InQuery),
+		WithAPIKey("X-Auth", "h34d3r", InHeader),
+		WithPayload(map[string]any{"user": map[string]any{"name": "neo", "password": "matrix", "pin": 1234}}),
+		WithHeaders(map[string]string{"Authorization": "Bearer abc", "X-Internal-Id": "int-7f3a"}),
+	)
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+
+	logged := buf.String()
+	for _, secret := range []string{"matrix", "1234", "Bearer abc", "int-7f3a", "s3cr3t", "t0k3n", "qk3y", "h34d3r"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("Expected %s to be redacted in %s", secret, logged)
+		}
+	}
+	for _, expected := range []string{"HTTP_TRAFFIC", "POST", `\"name\":\"neo\"`, "REDACTED", "bytes)"} {
+		if !strings.Contains(logged, expected) {
+			t.Errorf("Expected %s in %s", expected, logged)
+		}
+	}
+}
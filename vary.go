@@ -0,0 +1,93 @@
+package easyrqst
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+var errNoVariant = errors.New("no cached variant for the request headers")
+
+// varyNames lists the request headers a response varies on, and whether it can be
+// cached at all: Vary: * never matches a later request.
+func varyNames(header http.Header) ([]string, bool) {
+	var names []string
+	for _, value := range header.Values("Vary") {
+		for _, name := range strings.Split(value, ",") {
+			name = http.CanonicalHeaderKey(strings.TrimSpace(name))
+			if name == "*" {
+				return nil, false
+			}
+			if name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names, true
+}
+
+func variedValues(req *http.Request, names []string) map[string]string {
+	values := make(map[string]string, len(names))
+	for _, name := range names {
+		values[name] = strings.Join(req.Header.Values(name), ",")
+	}
+	return values
+}
+
+// variantKey is where the variant of key for the varied request header values is
+// stored. Values are hashed, as they may carry credentials.
+func variantKey(key string, values map[string]string) string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	digest := sha256.New()
+	for _, name := range names {
+		digest.Write([]byte(name + ":" + values[name] + "\n"))
+	}
+	return key + "_vary_" + hex.EncodeToString(digest.Sum(nil)[:12])
+}
+
+func sameVariant(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, value := range a {
+		if other, ok := b[name]; !ok || other != value {
+			return false
+		}
+	}
+	return true
+}
+
+// variant picks the cached variant negotiated for req. The entry stored under key is
+// the latest variant, which names the headers to match on; others are stored under
+// their variant key.
+func (h *easyRequest) variant(cache *cacheObj, key string, req *http.Request, latest *HttpResponse) (*HttpResponse, error) {
+	if len(latest.varied) == 0 {
+		return latest, nil
+	}
+	names := make([]string, 0, len(latest.varied))
+	for name := range latest.varied {
+		names = append(names, name)
+	}
+	values := variedValues(req, names)
+	if sameVariant(values, latest.varied) {
+		return latest, nil
+	}
+
+	cached, err := cache.fncs.Get(variantKey(key, values))
+	if err != nil {
+		return nil, errNoVariant
+	}
+	response, err := h.decodeCached(cached)
+	if err != nil || !sameVariant(values, response.varied) {
+		return nil, errNoVariant
+	}
+	return response, nil
+}
@@ -0,0 +1,47 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestVaryAwareCache(t *testing.T) {
+	calls := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		if r.URL.Path == "/any" {
+			w.Header().Set("Vary", "*")
+		} else {
+			w.Header().Set("Vary", "Accept-Language, accept-encoding")
+		}
+		w.Write([]byte(r.Header.Get("Accept-Language")))
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL, WithDefaults(WithCache(newMapCache(), time.Minute, "")))
+	get := func(path, language string) *HttpResponse {
+		resp, err := call.Get(WithPath(path), WithHeaders(map[string]string{"Accept-Language": language}))
+		if err != nil {
+			t.Errorf("Error: %v", err)
+			return &HttpResponse{}
+		}
+		return resp
+	}
+
+	for _, language := range []string{"en", "fr", "en", "fr", "de"} {
+		if resp := get("docs", language); string(resp.Body) != language {
+			t.Errorf("Expected the %s variant, got %q (from cache %v)", language, resp.Body, resp.FromCache)
+		}
+	}
+	if calls["/docs"] != 3 {
+		t.Errorf("Expected one request per variant, got %d", calls["/docs"])
+	}
+
+	get("any", "en")
+	get("any", "en")
+	if calls["/any"] != 2 {
+		t.Errorf("Expected Vary: * not to be cached, got %d calls", calls["/any"])
+	}
+}
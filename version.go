@@ -0,0 +1,101 @@
+package easyrqst
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+type versionLocation int
+
+const (
+	versionInPath versionLocation = iota
+	versionInHeader
+	versionInMediaType
+	versionInQuery
+)
+
+// VersionStrategy decides where WithAPIVersion puts the version on the request and
+// where the negotiated version is read from on the response.
+type VersionStrategy struct {
+	in   versionLocation
+	name string
+}
+
+// VersionInPath prefixes the request path with the version, e.g. /v2/users. This is
+// the default strategy.
+func VersionInPath() VersionStrategy {
+	return VersionStrategy{in: versionInPath}
+}
+
+// VersionInHeader sends the version in the named header. The server is expected to
+// echo the version it served in the same header.
+func VersionInHeader(name string) VersionStrategy {
+	return VersionStrategy{in: versionInHeader, name: name}
+}
+
+// VersionInMediaType sends Accept: application/vnd.<vendor>.<version>+json and reads
+// the negotiated version back from the response Content-Type.
+func VersionInMediaType(vendor string) VersionStrategy {
+	return VersionStrategy{in: versionInMediaType, name: vendor}
+}
+
+// VersionInQuery sends the version as the named query parameter.
+func VersionInQuery(name string) VersionStrategy {
+	return VersionStrategy{in: versionInQuery, name: name}
+}
+
+func WithVersionStrategy(strategy VersionStrategy) THttpOption {
+	return func(o *easyRequest) { o.versioning = strategy }
+}
+
+// WithAPIVersion requests the given API version using the client's version strategy.
+// Combine with WithDefaults to pin the version of every request.
+func WithAPIVersion(version string) TReqOption {
+	return func(o *ReqOptions) { o.apiVersion = version }
+}
+
+func (s VersionStrategy) mediaType(version string) string {
+	return fmt.Sprintf("application/vnd.%s.%s+json", s.name, version)
+}
+
+func (h *easyRequest) applyVersion(endpoint string, options *ReqOptions) string {
+	version := options.apiVersion
+	if version == "" {
+		return endpoint
+	}
+	switch h.versioning.in {
+	case versionInHeader:
+		options.headers[h.versioning.name] = version
+	case versionInMediaType:
+		options.headers["Accept"] = h.versioning.mediaType(version)
+	case versionInQuery:
+		options.queries[h.versioning.name] = version
+	default:
+		endpoint = strings.TrimRight(endpoint, "/") + "/" + strings.Trim(version, "/")
+	}
+	return endpoint
+}
+
+// negotiatedVersion reports the version the server answered with, falling back to the
+// requested one when the response does not say.
+func (h *easyRequest) negotiatedVersion(resp *http.Response, options *ReqOptions) string {
+	if options.apiVersion == "" {
+		return ""
+	}
+	switch h.versioning.in {
+	case versionInHeader:
+		if v := resp.Header.Get(h.versioning.name); v != "" {
+			return v
+		}
+	case versionInMediaType:
+		mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+		prefix := "application/vnd." + h.versioning.name + "."
+		if err == nil && strings.HasPrefix(mediaType, prefix) {
+			version, _, _ := strings.Cut(strings.TrimPrefix(mediaType, prefix), "+")
+			return version
+		}
+	}
+	return options.apiVersion
+}
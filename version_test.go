@@ -0,0 +1,45 @@
+package easyrqst
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIVersionStrategies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") == "application/vnd.acme.v2+json" {
+			w.Header().Set("Content-Type", "application/vnd.acme.v1+json")
+		}
+		if v := r.Header.Get("Api-Version"); v != "" {
+			w.Header().Set("Api-Version", v+".3")
+		}
+		w.Write([]byte(r.URL.RequestURI()))
+	}))
+	defer server.Close()
+
+	cases := []struct {
+		strategy VersionStrategy
+		uri      string
+		version  string
+	}{
+		{VersionInPath(), "/v2/users", "v2"},
+		{VersionInQuery("version"), "/users?version=v2", "v2"},
+		{VersionInHeader("Api-Version"), "/users", "v2.3"},
+		{VersionInMediaType("acme"), "/users", "v1"},
+	}
+	for _, c := range cases {
+		call := NewHttpClient(server.URL, WithVersionStrategy(c.strategy), WithDefaults(WithAPIVersion("v2")))
+		outcome, err := call.Get(WithPath("/users"))
+		if err != nil {
+			t.Errorf("Error: %v", err)
+			continue
+		}
+		if string(outcome.Body) != c.uri {
+			t.Errorf("Expected %s, got %s", c.uri, outcome.Body)
+		}
+		if outcome.APIVersion != c.version {
+			t.Errorf("Expected negotiated version %s, got %s", c.version, outcome.APIVersion)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package easyrqst
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	MethodPropfind  = "PROPFIND"
+	MethodProppatch = "PROPPATCH"
+	MethodMkcol     = "MKCOL"
+	MethodMove      = "MOVE"
+	MethodCopy      = "COPY"
+)
+
+const propfindAllProp = `<?xml version="1.0" encoding="utf-8"?><D:propfind xmlns:D="DAV:"><D:allprop/></D:propfind>`
+
+type MultiStatus struct {
+	Responses []DAVResponse
+}
+
+type DAVResponse struct {
+	Href       string
+	Status     int
+	Collection bool
+	Propstats  []DAVPropstat
+}
+
+type DAVPropstat struct {
+	Status int
+	Props  map[string]string
+}
+
+// Prop returns the value of a property from the first propstat that has it with a 2xx status.
+func (r *DAVResponse) Prop(name string) (string, bool) {
+	for _, ps := range r.Propstats {
+		if ps.Status < 200 || ps.Status >= 300 {
+			continue
+		}
+		if v, ok := ps.Props[name]; ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+type davMultiStatus struct {
+	Responses []struct {
+		Hrefs     []string `xml:"DAV: href"`
+		Status    string   `xml:"DAV: status"`
+		Propstats []struct {
+			Prop struct {
+				Elements []xmlElement `xml:",any"`
+			} `xml:"DAV: prop"`
+			Status string `xml:"DAV: status"`
+		} `xml:"DAV: propstat"`
+	} `xml:"DAV: response"`
+}
+
+// WithDepth sets the WebDAV Depth header: "0", "1" or "infinity".
+func WithDepth(depth string) TReqOption {
+	return WithMergedHeaders(map[string]string{"Depth": depth})
+}
+
+// WithDestination sets the target of a MOVE or COPY.
+func WithDestination(destination string, overwrite bool) TReqOption {
+	flag := "F"
+	if overwrite {
+		flag = "T"
+	}
+	return WithMergedHeaders(map[string]string{"Destination": destination, "Overwrite": flag})
+}
+
+// Propfind lists the properties of the resource at the client endpoint. Without a
+// payload all properties are requested.
+func Propfind(client IHttpClient, depth string, opts ...TReqOption) (*MultiStatus, *HttpResponse, error) {
+	// Applied last, so they hold whatever the client defaults and opts set
+	opts = append(opts[:len(opts):len(opts)], WithDepth(depth), propfindBody)
+	outcome, err := client.Custom(MethodPropfind, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if outcome.StatusCode != http.StatusMultiStatus {
+		return nil, outcome, fmt.Errorf("expected status code 207, got %d", outcome.StatusCode)
+	}
+	ms, err := ParseMultiStatus(outcome.Body)
+	return ms, outcome, err
+}
+
+// propfindBody asks for all properties unless a payload was given, as XML unless
+// another content type was set.
+func propfindBody(o *ReqOptions) {
+	if _, set := o.headers["Content-Type"]; !set {
+		o.headers["Content-Type"] = "application/xml"
+	}
+	if o.rawBody == nil && o.payload == nil && o.files == nil {
+		o.rawBody = []byte(propfindAllProp)
+	}
+}
+
+func Mkcol(client IHttpClient, opts ...TReqOption) (*HttpResponse, error) {
+	return client.Custom(MethodMkcol, opts...)
+}
+
+func Move(client IHttpClient, destination string, overwrite bool, opts ...TReqOption) (*HttpResponse, error) {
+	return client.Custom(MethodMove, append(opts[:len(opts):len(opts)], WithDestination(destination, overwrite))...)
+}
+
+func Copy(client IHttpClient, destination string, overwrite bool, opts ...TReqOption) (*HttpResponse, error) {
+	return client.Custom(MethodCopy, append(opts[:len(opts):len(opts)], WithDestination(destination, overwrite))...)
+}
+
+// ParseMultiStatus parses a 207 Multi-Status body into per-resource results.
+func ParseMultiStatus(body []byte) (*MultiStatus, error) {
+	var raw davMultiStatus
+	if err := xml.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse multistatus: %v", err)
+	}
+
+	ms := &MultiStatus{}
+	for _, r := range raw.Responses {
+		status := parseDAVStatus(r.Status)
+		for _, href := range r.Hrefs {
+			resp := DAVResponse{Href: href, Status: status}
+			for _, ps := range r.Propstats {
+				propstat := DAVPropstat{Status: parseDAVStatus(ps.Status), Props: make(map[string]string)}
+				for _, el := range ps.Prop.Elements {
+					propstat.Props[el.XMLName.Local] = strings.TrimSpace(el.Content)
+					if el.XMLName.Local == "resourcetype" {
+						for _, child := range el.Children {
+							resp.Collection = resp.Collection || child.XMLName.Local == "collection"
+						}
+					}
+				}
+				resp.Propstats = append(resp.Propstats, propstat)
+			}
+			ms.Responses = append(ms.Responses, resp)
+		}
+	}
+	return ms, nil
+}
+
+// parseDAVStatus turns "HTTP/1.1 200 OK" into 200, returning 0 when absent.
+func parseDAVStatus(status string) int {
+	fields := strings.Fields(status)
+	if len(fields) < 2 {
+		return 0
+	}
+	code, _ := strconv.Atoi(fields[1])
+	return code
+}
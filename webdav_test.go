@@ -0,0 +1,110 @@
+package easyrqst
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const multiStatusBody = `<?xml version="1.0" encoding="utf-8"?>
+<D:multistatus xmlns:D="DAV:">
+  <D:response>
+    <D:href>/files/</D:href>
+    <D:propstat>
+      <D:prop><D:displayname>files</D:displayname><D:resourcetype><D:collection/></D:resourcetype></D:prop>
+      <D:status>HTTP/1.1 200 OK</D:status>
+    </D:propstat>
+  </D:response>
+  <D:response>
+    <D:href>/files/report.pdf</D:href>
+    <D:propstat>
+      <D:prop><D:getcontentlength>1024</D:getcontentlength><D:resourcetype/></D:prop>
+      <D:status>HTTP/1.1 200 OK</D:status>
+    </D:propstat>
+    <D:propstat>
+      <D:prop><D:quota/></D:prop>
+      <D:status>HTTP/1.1 404 Not Found</D:status>
+    </D:propstat>
+  </D:response>
+</D:multistatus>`
+
+func TestPropfind(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != MethodPropfind || r.Header.Get("Depth") != "1" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusMultiStatus)
+		w.Write([]byte(multiStatusBody))
+	}))
+	defer server.Close()
+
+	ms, _, err := Propfind(NewHttpClient(server.URL), "1", WithHeaders(map[string]string{"Authorization": "Bearer t0k3n"}))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if len(ms.Responses) != 2 {
+		t.Errorf("Expected 2 resources, got %v", len(ms.Responses))
+		return
+	}
+	if !ms.Responses[0].Collection || ms.Responses[1].Collection {
+		t.Errorf("Expected only /files/ to be a collection")
+	}
+	if size, ok := ms.Responses[1].Prop("getcontentlength"); !ok || size != "1024" {
+		t.Errorf("Expected content length 1024, got %q", size)
+	}
+	if _, ok := ms.Responses[1].Prop("quota"); ok {
+		t.Errorf("Expected quota to be reported as not found")
+	}
+}
+
+func TestPropfindPayload(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.WriteHeader(http.StatusMultiStatus)
+		w.Write([]byte(multiStatusBody))
+	}))
+	defer server.Close()
+
+	call := NewHttpClient(server.URL)
+	if _, _, err := Propfind(call, "0", WithPayload(map[string]interface{}{"propfind": map[string]interface{}{"prop": "quota"}})); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if _, _, err := Propfind(call, "0"); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	defaulted := NewHttpClient(server.URL, WithDefaults(WithRawBody([]byte("<propfind/>"))))
+	if _, _, err := Propfind(defaulted, "0"); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if strings.Contains(bodies[0], "allprop") || !strings.Contains(bodies[0], "quota") {
+		t.Errorf("Expected the payload to replace allprop, got %s", bodies[0])
+	}
+	if bodies[1] != propfindAllProp {
+		t.Errorf("Expected allprop without a payload, got %s", bodies[1])
+	}
+	if bodies[2] != "<propfind/>" {
+		t.Errorf("Expected the client default body to be kept, got %s", bodies[2])
+	}
+}
+
+func TestMoveSetsDestination(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Method + " " + r.Header.Get("Destination") + " " + r.Header.Get("Overwrite")))
+	}))
+	defer server.Close()
+
+	outcome, err := Move(NewHttpClient(server.URL), "/files/new.pdf", false, WithHeaders(map[string]string{"Authorization": "Bearer t0k3n"}))
+	if err != nil {
+		t.Errorf("Error: %v", err)
+		return
+	}
+	if string(outcome.Body) != "MOVE /files/new.pdf F" {
+		t.Errorf("Unexpected request %s", outcome.Body)
+	}
+}